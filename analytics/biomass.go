@@ -0,0 +1,39 @@
+package analytics
+
+import (
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+var Diets = []sim.Diet{sim.Herbivore, sim.Funghi}
+
+type Biomass struct {
+	Diet    string `json:"diet"`
+	Biomass int    `json:"biomass"`
+	Cells   int    `json:"cells"`
+}
+
+// Cells with more than one diet count towards each of them
+func getBiomass(organisms sim.OrganismList) []Biomass {
+	biomass := make([]Biomass, len(Diets))
+	for dietIndex, diet := range Diets {
+		biomass[dietIndex].Diet = diet.String()
+	}
+
+	for _, organism := range organisms {
+		for _, cell := range organism.GetCells() {
+			if !cell.IsAlive() {
+				continue
+			}
+
+			ct := cell.GetType()
+			for dietIndex, diet := range Diets {
+				if sim.HasDiet(diet, ct.GetDiet()) {
+					biomass[dietIndex].Biomass += ct.GetMass()
+					biomass[dietIndex].Cells++
+				}
+			}
+		}
+	}
+
+	return biomass
+}
@@ -0,0 +1,45 @@
+package analytics
+
+import "math"
+
+type Diversity struct {
+	Shannon  float64 `json:"shannon"`
+	Simpson  float64 `json:"simpson"`
+	Richness int     `json:"richness"`
+	Evenness float64 `json:"evenness"`
+}
+
+func getDiversity(abundance []int) Diversity {
+	total := 0
+	richness := 0
+	for _, count := range abundance {
+		if count > 0 {
+			total += count
+			richness++
+		}
+	}
+
+	d := Diversity{Richness: richness}
+	if total == 0 {
+		return d
+	}
+
+	dominance := float64(0)
+	for _, count := range abundance {
+		if count > 0 {
+			p := float64(count) / float64(total)
+			d.Shannon -= p * math.Log(p)
+			dominance += p * p
+		}
+	}
+
+	// Gini-Simpson index, probability that two random organisms differ
+	d.Simpson = 1 - dominance
+
+	// Pielou's evenness is undefined for single species
+	if richness > 1 {
+		d.Evenness = d.Shannon / math.Log(float64(richness))
+	}
+
+	return d
+}
@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type Report struct {
+	Iteration int         `json:"iteration"`
+	Diversity Diversity   `json:"diversity"`
+	Traits    []Histogram `json:"traits"`
+	Biomass   []Biomass   `json:"biomass"`
+}
+
+// Compute must be called while holding sim lock
+func Compute(s *sim.Sim) Report {
+	return FromOrganisms(s.GetIteration(), s.GetOrganisms().GetAlive())
+}
+
+func FromOrganisms(iteration int, organisms sim.OrganismList) Report {
+	counts := map[int]int{}
+	species := sim.SpeciesList{}
+
+	for _, organism := range organisms {
+		sp := organism.GetSpecies()
+		if _, found := counts[sp.GetID()]; !found {
+			species = append(species, sp)
+		}
+		counts[sp.GetID()]++
+	}
+
+	abundance := make([]int, len(species))
+	for speciesIndex, sp := range species {
+		abundance[speciesIndex] = counts[sp.GetID()]
+	}
+
+	return Report{
+		Iteration: iteration,
+		Diversity: getDiversity(abundance),
+		Traits:    getTraitHistograms(organisms),
+		Biomass:   getBiomass(organisms),
+	}
+}
+
+// GetDominantDiet returns diet with the highest biomass
+func (r Report) GetDominantDiet() string {
+	dominant := Biomass{}
+	for _, biomass := range r.Biomass {
+		if biomass.Biomass > dominant.Biomass {
+			dominant = biomass
+		}
+	}
+
+	return dominant.Diet
+}
@@ -0,0 +1,116 @@
+package analytics
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func TestDiversity(t *testing.T) {
+	t.Run("single species has no diversity", func(t *testing.T) {
+		// When
+		d := getDiversity([]int{10})
+
+		// Then
+		if d.Richness != 1 {
+			t.Errorf("Expected 1, got %d", d.Richness)
+		}
+		if d.Shannon != 0 || d.Simpson != 0 || d.Evenness != 0 {
+			t.Errorf("Expected no diversity, got %+v", d)
+		}
+	})
+
+	t.Run("even species are perfectly even", func(t *testing.T) {
+		// When
+		d := getDiversity([]int{5, 5, 5, 5, 0})
+
+		// Then
+		if d.Richness != 4 {
+			t.Errorf("Expected 4, got %d", d.Richness)
+		}
+		if math.Abs(d.Shannon-math.Log(4)) > 1e-9 {
+			t.Errorf("Expected %.4f, got %.4f", math.Log(4), d.Shannon)
+		}
+		if math.Abs(d.Simpson-.75) > 1e-9 {
+			t.Errorf("Expected 0.75, got %.4f", d.Simpson)
+		}
+		if math.Abs(d.Evenness-1) > 1e-9 {
+			t.Errorf("Expected 1, got %.4f", d.Evenness)
+		}
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	// When
+	h := getHistogram(TraitSize, []float64{0, .5, 5, 9.5, 10})
+
+	// Then
+	if len(h.Bins) != histogramBins {
+		t.Fatalf("Expected %d bins, got %d", histogramBins, len(h.Bins))
+	}
+	if h.Bins[0].Count != 2 {
+		t.Errorf("Expected 2 in first bin, got %d", h.Bins[0].Count)
+	}
+	if h.Bins[histogramBins-1].Count != 2 {
+		t.Errorf("Expected 2 in last bin, got %d", h.Bins[histogramBins-1].Count)
+	}
+	if h.Mean != 5 {
+		t.Errorf("Expected mean 5, got %.2f", h.Mean)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	// Given
+	s := sim.Sim{}
+	s.Create(sim.SimConfig{StartCells: 10})
+
+	// When
+	report := Compute(&s)
+
+	// Then
+	if report.Diversity.Richness != 10 {
+		t.Errorf("Expected 10, got %d", report.Diversity.Richness)
+	}
+	if len(report.Traits) != len(Traits) {
+		t.Errorf("Expected %d, got %d", len(Traits), len(report.Traits))
+	}
+	if report.Biomass[0].Cells != 10 {
+		t.Errorf("Expected 10 herbivore cells, got %d", report.Biomass[0].Cells)
+	}
+}
+
+func TestTraitHistograms(t *testing.T) {
+	// Given
+	s := sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+	for it := 0; it < 50; it++ {
+		s.RunStep(context.TODO())
+	}
+	organisms := s.GetOrganisms().GetAlive()
+	aliveCells := 0
+	for _, organism := range organisms {
+		aliveCells += organism.GetCells().GetAliveCount()
+	}
+
+	// When
+	histograms := getTraitHistograms(organisms)
+
+	// Then
+	for _, histogram := range histograms {
+		count := 0
+		for _, bin := range histogram.Bins {
+			count += bin.Count
+		}
+		if count != aliveCells {
+			t.Errorf("Expected %d, got %d", aliveCells, count)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package analytics
+
+import (
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+const histogramBins = 10
+
+type Trait string
+
+const (
+	TraitHerbivore      = Trait("herbivore")
+	TraitFunghi         = Trait("funghi")
+	TraitWasteTolerance = Trait("wasteTolerance")
+	TraitConnects       = Trait("connects")
+	TraitMobility       = Trait("mobility")
+	TraitSize           = Trait("size")
+)
+
+var Traits = []Trait{
+	TraitHerbivore,
+	TraitFunghi,
+	TraitWasteTolerance,
+	TraitConnects,
+	TraitMobility,
+	TraitSize,
+}
+
+func (t Trait) Value(ct sim.CellType) float64 {
+	switch t {
+	case TraitHerbivore:
+		return float64(ct.Herbivore)
+	case TraitFunghi:
+		return float64(ct.Funghi)
+	case TraitWasteTolerance:
+		return ct.GetWasteTolerance()
+	case TraitConnects:
+		return float64(ct.GetConnects())
+	case TraitMobility:
+		return float64(ct.GetMobility())
+	case TraitSize:
+		return float64(ct.GetSize())
+	}
+
+	return 0
+}
+
+type HistogramBin struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Count int     `json:"count"`
+}
+
+type Histogram struct {
+	Trait Trait          `json:"trait"`
+	Min   float64        `json:"min"`
+	Max   float64        `json:"max"`
+	Mean  float64        `json:"mean"`
+	Bins  []HistogramBin `json:"bins"`
+}
+
+func getHistogram(trait Trait, values []float64) Histogram {
+	h := Histogram{
+		Trait: trait,
+		Bins:  []HistogramBin{},
+	}
+	if len(values) == 0 {
+		return h
+	}
+
+	h.Min = values[0]
+	h.Max = values[0]
+	sum := float64(0)
+	for _, value := range values {
+		if h.Min > value {
+			h.Min = value
+		}
+		if h.Max < value {
+			h.Max = value
+		}
+		sum += value
+	}
+	h.Mean = sum / float64(len(values))
+
+	if h.Min == h.Max {
+		h.Bins = []HistogramBin{{h.Min, h.Max, len(values)}}
+		return h
+	}
+
+	width := (h.Max - h.Min) / histogramBins
+	h.Bins = make([]HistogramBin, histogramBins)
+	for binIndex := range h.Bins {
+		h.Bins[binIndex].Start = h.Min + width*float64(binIndex)
+		h.Bins[binIndex].End = h.Min + width*float64(binIndex+1)
+	}
+
+	for _, value := range values {
+		binIndex := int((value - h.Min) / width)
+		if binIndex >= histogramBins {
+			binIndex = histogramBins - 1
+		}
+		h.Bins[binIndex].Count++
+	}
+
+	return h
+}
+
+// Every living cell counts once, so cell types weigh as much as the number of
+// cells using them
+func getTraitHistograms(organisms sim.OrganismList) []Histogram {
+	types := []*sim.CellType{}
+	for _, organism := range organisms {
+		for _, cell := range organism.GetCells() {
+			if cell.IsAlive() {
+				types = append(types, cell.GetType())
+			}
+		}
+	}
+
+	histograms := make([]Histogram, len(Traits))
+	for traitIndex, trait := range Traits {
+		values := make([]float64, len(types))
+		for typeIndex, ct := range types {
+			values[typeIndex] = trait.Value(*ct)
+		}
+
+		histograms[traitIndex] = getHistogram(trait, values)
+	}
+
+	return histograms
+}
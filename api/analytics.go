@@ -0,0 +1,105 @@
+package api
+
+import (
+	"github.com/dominik-zeglen/aquarium/analytics"
+)
+
+type DiversityResolver struct {
+	d analytics.Diversity
+}
+
+func (res DiversityResolver) Evenness() float64 {
+	return res.d.Evenness
+}
+func (res DiversityResolver) Richness() int32 {
+	return int32(res.d.Richness)
+}
+func (res DiversityResolver) Shannon() float64 {
+	return res.d.Shannon
+}
+func (res DiversityResolver) Simpson() float64 {
+	return res.d.Simpson
+}
+
+type HistogramBinResolver struct {
+	bin analytics.HistogramBin
+}
+
+func (res HistogramBinResolver) Count() int32 {
+	return int32(res.bin.Count)
+}
+func (res HistogramBinResolver) End() float64 {
+	return res.bin.End
+}
+func (res HistogramBinResolver) Start() float64 {
+	return res.bin.Start
+}
+
+type TraitHistogramResolver struct {
+	h analytics.Histogram
+}
+
+func (res TraitHistogramResolver) Bins() []HistogramBinResolver {
+	resolvers := make([]HistogramBinResolver, len(res.h.Bins))
+
+	for binIndex := range res.h.Bins {
+		resolvers[binIndex] = HistogramBinResolver{res.h.Bins[binIndex]}
+	}
+
+	return resolvers
+}
+func (res TraitHistogramResolver) Max() float64 {
+	return res.h.Max
+}
+func (res TraitHistogramResolver) Mean() float64 {
+	return res.h.Mean
+}
+func (res TraitHistogramResolver) Min() float64 {
+	return res.h.Min
+}
+func (res TraitHistogramResolver) Trait() string {
+	return string(res.h.Trait)
+}
+
+type DietBiomassResolver struct {
+	b analytics.Biomass
+}
+
+func (res DietBiomassResolver) Biomass() int32 {
+	return int32(res.b.Biomass)
+}
+func (res DietBiomassResolver) Cells() int32 {
+	return int32(res.b.Cells)
+}
+func (res DietBiomassResolver) Diet() string {
+	return res.b.Diet
+}
+
+type AnalyticsResolver struct {
+	report analytics.Report
+}
+
+func (res AnalyticsResolver) Biomass() []DietBiomassResolver {
+	resolvers := make([]DietBiomassResolver, len(res.report.Biomass))
+
+	for biomassIndex := range res.report.Biomass {
+		resolvers[biomassIndex] = DietBiomassResolver{res.report.Biomass[biomassIndex]}
+	}
+
+	return resolvers
+}
+func (res AnalyticsResolver) Diversity() DiversityResolver {
+	return DiversityResolver{res.report.Diversity}
+}
+func (res AnalyticsResolver) Iteration() int32 {
+	return int32(res.report.Iteration)
+}
+func (res AnalyticsResolver) Traits() []TraitHistogramResolver {
+	resolvers := make([]TraitHistogramResolver, len(res.report.Traits))
+
+	for traitIndex := range res.report.Traits {
+		resolvers[traitIndex] = TraitHistogramResolver{res.report.Traits[traitIndex]}
+	}
+
+	return resolvers
+}
@@ -6,14 +6,15 @@ import (
 )
 
 type CellResolver struct {
-	cell sim.Cell
+	cell      sim.Cell
+	iteration int
 }
 
-func createCellResolverList(cells sim.CellList) []CellResolver {
+func createCellResolverList(cells sim.CellList, iteration int) []CellResolver {
 	resolvers := make([]CellResolver, len(cells))
 
 	for speciesIndex := range cells {
-		resolvers[speciesIndex] = CellResolver{cells[speciesIndex]}
+		resolvers[speciesIndex] = CellResolver{cells[speciesIndex], iteration}
 	}
 
 	return resolvers
@@ -23,12 +24,35 @@ func (res CellResolver) ID() int32 {
 	return int32(res.cell.GetID())
 }
 
+func (res CellResolver) Age() int32 {
+	return int32(res.iteration - res.cell.GetBornAt())
+}
 func (res CellResolver) Alive() bool {
 	return res.cell.IsAlive()
 }
+func (res CellResolver) BornAt() int32 {
+	return int32(res.cell.GetBornAt())
+}
+func (res CellResolver) Capacity() int32 {
+	return int32(res.cell.GetCapacity())
+}
+func (res CellResolver) DiedAt() *int32 {
+	if res.cell.IsAlive() {
+		return nil
+	}
+
+	diedAt := int32(res.cell.GetDiedAt())
+	return &diedAt
+}
 func (res CellResolver) Hp() int32 {
 	return int32(res.cell.GetHP())
 }
+func (res CellResolver) ProcreatedAt() int32 {
+	return int32(res.cell.GetProcreatedAt())
+}
+func (res CellResolver) Satiation() int32 {
+	return int32(res.cell.GetSatiation())
+}
 
 func (res CellResolver) Position() r2.Point {
 	return res.cell.GetPosition()
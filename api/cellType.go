@@ -22,6 +22,24 @@ func (res CellTypeResolver) ID() int32 {
 	return int32(res.cellType.ID)
 }
 
+func (res CellTypeResolver) Attack() int32 {
+	return int32(res.cellType.GetAttack())
+}
+func (res CellTypeResolver) CanConnect() bool {
+	return res.cellType.CanConnect()
+}
+func (res CellTypeResolver) Carnivore() int32 {
+	return int32(res.cellType.Carnivore)
+}
+func (res CellTypeResolver) Connects() int32 {
+	return int32(res.cellType.GetConnects())
+}
+func (res CellTypeResolver) Consumption() int32 {
+	return int32(res.cellType.GetConsumption())
+}
+func (res CellTypeResolver) Defence() int32 {
+	return int32(res.cellType.GetDefence())
+}
 func (res CellTypeResolver) Diet() []string {
 	diets := res.cellType.GetDiet()
 	dietNames := make([]string, len(diets))
@@ -32,9 +50,54 @@ func (res CellTypeResolver) Diet() []string {
 
 	return dietNames
 }
+func (res CellTypeResolver) Enzymes() int32 {
+	return int32(res.cellType.GetEnzymes())
+}
+func (res CellTypeResolver) FoodValue() int32 {
+	return int32(res.cellType.GetFoodValue())
+}
 func (res CellTypeResolver) Funghi() int32 {
 	return int32(res.cellType.Funghi)
 }
 func (res CellTypeResolver) Herbivore() int32 {
 	return int32(res.cellType.Herbivore)
 }
+func (res CellTypeResolver) Mass() int32 {
+	return int32(res.cellType.GetMass())
+}
+func (res CellTypeResolver) MaxCapacity() int32 {
+	return int32(res.cellType.GetMaxCapacity())
+}
+func (res CellTypeResolver) MaxHp() int32 {
+	return int32(res.cellType.GetMaxHP())
+}
+func (res CellTypeResolver) MaxSatiation() int32 {
+	return int32(res.cellType.GetMaxSatiation())
+}
+func (res CellTypeResolver) Membrane() int32 {
+	return int32(res.cellType.GetMembrane())
+}
+func (res CellTypeResolver) Mobility() int32 {
+	return int32(res.cellType.GetMobility())
+}
+func (res CellTypeResolver) Points() int32 {
+	return int32(res.cellType.GetPoints())
+}
+func (res CellTypeResolver) ProcreationCd() int32 {
+	return int32(res.cellType.GetProcreationCd())
+}
+func (res CellTypeResolver) Shape() string {
+	return res.cellType.GetShape()
+}
+func (res CellTypeResolver) Size() int32 {
+	return int32(res.cellType.GetSize())
+}
+func (res CellTypeResolver) TimeToDie() int32 {
+	return int32(res.cellType.GetTimeToDie())
+}
+func (res CellTypeResolver) Transport() int32 {
+	return int32(res.cellType.GetTransport())
+}
+func (res CellTypeResolver) WasteTolerance() float64 {
+	return res.cellType.GetWasteTolerance()
+}
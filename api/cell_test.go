@@ -5,15 +5,24 @@ import (
 	"log"
 	"testing"
 
+	"github.com/dominik-zeglen/aquarium/registry"
 	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
 )
 
 func TestCellResolver(t *testing.T) {
 	// Given
-	s := sim.Sim{}
-	s.Create(sim.SimConfig{})
-	d := sim.IterationData{}
-	schema, err := GetSchema(&s, &d)
+	config := sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		StartCells:         10,
+	}
+	r := registry.New(config)
+	if _, err := r.Create(registry.DefaultID, config); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := GetSchema(r, timelapse.NewStore())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -28,17 +37,44 @@ func TestCellResolver(t *testing.T) {
 				id
 				cells {
 					id
+					age
 					alive
+					bornAt
+					capacity
+					diedAt
 					hp
 					position {
 						x
 						y
 					}
+					procreatedAt
+					satiation
 					type {
 						id
+						attack
+						canConnect
+						carnivore
+						connects
+						consumption
+						defence
 						diet
+						enzymes
+						foodValue
 						funghi
 						herbivore
+						mass
+						maxCapacity
+						maxHp
+						maxSatiation
+						membrane
+						mobility
+						points
+						procreationCd
+						shape
+						size
+						timeToDie
+						transport
+						wasteTolerance
 					}
 				}
 			}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  = SortDirection("ASC")
+	SortDirectionDesc = SortDirection("DESC")
+)
+
+type OrganismSortField string
+
+const (
+	OrganismSortFieldAge  = OrganismSortField("AGE")
+	OrganismSortFieldID   = OrganismSortField("ID")
+	OrganismSortFieldSize = OrganismSortField("SIZE")
+)
+
+type OrganismSortInput struct {
+	Field     string
+	Direction string
+}
+
+type PageInfoResolver struct {
+	EndCursor       *string
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+}
+
+type OrganismEdgeResolver struct {
+	Cursor string
+	Node   OrganismResolver
+}
+
+type OrganismConnectionResolver struct {
+	Edges      []OrganismEdgeResolver
+	PageInfo   PageInfoResolver
+	TotalCount int32
+}
+
+// Cursor holds sort key and ID of organism, so pages stay consistent even if
+// organisms before cursor died in the meantime
+type cursor struct {
+	key int
+	id  int
+}
+
+func (c cursor) String() string {
+	return base64.StdEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("organism:%d:%d", c.key, c.id)),
+	)
+}
+
+func parseCursor(value string) (cursor, error) {
+	c := cursor{}
+
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return c, fmt.Errorf("Invalid cursor %s", value)
+	}
+	if _, err := fmt.Sscanf(string(data), "organism:%d:%d", &c.key, &c.id); err != nil {
+		return c, fmt.Errorf("Invalid cursor %s", value)
+	}
+
+	return c, nil
+}
+
+// isAfter tells if c is placed after other in ascending order
+func (c cursor) isAfter(other cursor) bool {
+	return c.key > other.key || (c.key == other.key && c.id > other.id)
+}
+
+func getSortKey(field OrganismSortField) (func(o sim.Organism) int, error) {
+	switch field {
+	case OrganismSortFieldAge:
+		// Organisms born earlier are older
+		return func(o sim.Organism) int { return -o.GetBornAt() }, nil
+	case OrganismSortFieldID:
+		return func(o sim.Organism) int { return o.GetID() }, nil
+	case OrganismSortFieldSize:
+		return func(o sim.Organism) int { return len(o.GetCells()) }, nil
+	}
+
+	return nil, fmt.Errorf("Unknown sort field %s", field)
+}
+
+func createOrganismConnection(
+	organisms sim.OrganismList,
+	s *sim.Snapshot,
+	sortInput *OrganismSortInput,
+	first *int32,
+	after *string,
+) (OrganismConnectionResolver, error) {
+	connection := OrganismConnectionResolver{
+		Edges:      []OrganismEdgeResolver{},
+		TotalCount: int32(len(organisms)),
+	}
+
+	field := OrganismSortFieldID
+	direction := SortDirectionAsc
+	if sortInput != nil {
+		field = OrganismSortField(sortInput.Field)
+		direction = SortDirection(sortInput.Direction)
+	}
+	getKey, err := getSortKey(field)
+	if err != nil {
+		return connection, err
+	}
+
+	cursors := make([]cursor, len(organisms))
+	order := make([]int, len(organisms))
+	for organismIndex, organism := range organisms {
+		cursors[organismIndex] = cursor{getKey(organism), organism.GetID()}
+		order[organismIndex] = organismIndex
+	}
+
+	isAfter := func(a cursor, b cursor) bool {
+		if direction == SortDirectionDesc {
+			return b.isAfter(a)
+		}
+		return a.isAfter(b)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return isAfter(cursors[order[j]], cursors[order[i]])
+	})
+
+	start := 0
+	if after != nil {
+		afterCursor, err := parseCursor(*after)
+		if err != nil {
+			return connection, err
+		}
+		start = sort.Search(len(order), func(i int) bool {
+			return isAfter(cursors[order[i]], afterCursor)
+		})
+	}
+
+	end := len(order)
+	if first != nil {
+		if *first < 0 {
+			return connection, fmt.Errorf("First must not be negative, got %d", *first)
+		}
+		if start+int(*first) < end {
+			end = start + int(*first)
+		}
+	}
+
+	for _, organismIndex := range order[start:end] {
+		connection.Edges = append(connection.Edges, OrganismEdgeResolver{
+			Cursor: cursors[organismIndex].String(),
+			Node:   OrganismResolver{organisms[organismIndex], s},
+		})
+	}
+
+	connection.PageInfo.HasPreviousPage = start > 0
+	connection.PageInfo.HasNextPage = end < len(order)
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection, nil
+}
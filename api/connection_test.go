@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/timelapse"
+	"github.com/golang/geo/r2"
+)
+
+type organismPage struct {
+	OrganismList struct {
+		Edges []struct {
+			Node struct {
+				ID    int
+				Cells []struct{ ID int }
+			}
+		}
+		PageInfo struct {
+			EndCursor   *string
+			HasNextPage bool
+		}
+		TotalCount int
+	}
+}
+
+const organismListQuery = `query OrganismList(
+	$after: String
+	$filter: OrganismFilter
+	$sort: OrganismSortInput
+) {
+	organismList(first: 3, after: $after, filter: $filter, sort: $sort) {
+		edges {
+			node {
+				id
+				cells {
+					id
+				}
+			}
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+		totalCount
+	}
+}`
+
+func TestOrganismConnection(t *testing.T) {
+	r, s := getTestRegistry(t)
+	for it := 0; it < 50; it++ {
+		runStep(s)
+	}
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getPage := func(variables map[string]interface{}) organismPage {
+		res := schema.Exec(context.TODO(), organismListQuery, "OrganismList", variables)
+		if len(res.Errors) > 0 {
+			t.Fatal(res.Errors)
+		}
+
+		var page organismPage
+		if err := json.Unmarshal(res.Data, &page); err != nil {
+			t.Fatal(err)
+		}
+
+		return page
+	}
+
+	t.Run("paginates through all organisms", func(t *testing.T) {
+		// Given
+		ids := map[int]bool{}
+		variables := map[string]interface{}{}
+
+		// When
+		var page organismPage
+		for {
+			page = getPage(variables)
+			for _, edge := range page.OrganismList.Edges {
+				if ids[edge.Node.ID] {
+					t.Errorf("Organism %d returned twice", edge.Node.ID)
+				}
+				ids[edge.Node.ID] = true
+			}
+			if !page.OrganismList.PageInfo.HasNextPage {
+				break
+			}
+			variables["after"] = *page.OrganismList.PageInfo.EndCursor
+		}
+
+		// Then
+		if len(ids) != page.OrganismList.TotalCount {
+			t.Errorf("Expected %d, got %d", page.OrganismList.TotalCount, len(ids))
+		}
+		if len(ids) != s.GetCellCount() {
+			t.Errorf("Expected %d, got %d", s.GetCellCount(), len(ids))
+		}
+	})
+
+	t.Run("sorts and filters organisms", func(t *testing.T) {
+		// Given
+		variables := map[string]interface{}{
+			"filter": map[string]interface{}{
+				"alive": true,
+				"cells": map[string]interface{}{"max": 1},
+			},
+			"sort": map[string]interface{}{
+				"field":     "ID",
+				"direction": "DESC",
+			},
+		}
+
+		// When
+		page := getPage(variables)
+
+		// Then
+		edges := page.OrganismList.Edges
+		if len(edges) == 0 {
+			t.Fatal("Expected organisms")
+		}
+		for edgeIndex, edge := range edges {
+			if len(edge.Node.Cells) > 1 {
+				t.Errorf("Expected at most 1 cell, got %d", len(edge.Node.Cells))
+			}
+			if edgeIndex > 0 && edges[edgeIndex-1].Node.ID < edge.Node.ID {
+				t.Error("Expected organisms sorted by descending ID")
+			}
+		}
+	})
+
+	t.Run("returns alive organisms in area by default", func(t *testing.T) {
+		// Given
+		s.Lock()
+		defer s.Unlock()
+		if _, err := s.KillOrganism(s.GetOrganisms()[0].GetID()); err != nil {
+			t.Fatal(err)
+		}
+		start := r2.Point{X: 0, Y: 0}
+		end := r2.Point{X: 1e6, Y: 1e6}
+		filter := OrganismFilter{Area: &AreaInput{Start: start, End: end}}
+		expected := s.GetOrganisms().GetAlive().GetAreaCount(start, end)
+
+		// When
+		organisms := filter.apply(s.GetOrganisms(), s.GetIteration())
+
+		// Then
+		if len(organisms) != expected {
+			t.Errorf("Expected %d, got %d", expected, len(organisms))
+		}
+	})
+
+	t.Run("rejects invalid cursor", func(t *testing.T) {
+		// When
+		res := schema.Exec(
+			context.TODO(),
+			organismListQuery,
+			"OrganismList",
+			map[string]interface{}{"after": "invalid"},
+		)
+
+		// Then
+		if len(res.Errors) == 0 {
+			t.Error("Expected error")
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import "github.com/dominik-zeglen/aquarium/sim"
+
+const (
+	defaultEnvironmentSamples = 20
+	maxEnvironmentSamples     = 1000
+)
+
+type EnvironmentSampleResolver struct {
+	Height float64
+	Value  float64
+}
+
+type EnvironmentResolver struct {
+	env       sim.Environment
+	iteration int
+	samples   int
+}
+
+func (res EnvironmentResolver) Height() int32 {
+	return int32(res.env.GetHeight())
+}
+func (res EnvironmentResolver) Hour() int32 {
+	return int32(sim.GetHour(res.iteration))
+}
+func (res EnvironmentResolver) Iteration() int32 {
+	return int32(res.iteration)
+}
+func (res EnvironmentResolver) Toxicity() float64 {
+	return res.env.GetToxicity()
+}
+func (res EnvironmentResolver) Width() int32 {
+	return int32(res.env.GetWidth())
+}
+
+// getProfile samples value evenly from the top to the bottom of environment
+func (res EnvironmentResolver) getProfile(
+	getValue func(height float64) float64,
+) []EnvironmentSampleResolver {
+	resolvers := make([]EnvironmentSampleResolver, res.samples)
+	step := float64(res.env.GetHeight()) / float64(res.samples-1)
+
+	for sampleIndex := range resolvers {
+		height := step * float64(sampleIndex)
+		resolvers[sampleIndex] = EnvironmentSampleResolver{height, getValue(height)}
+	}
+
+	return resolvers
+}
+
+func (res EnvironmentResolver) LightProfile() []EnvironmentSampleResolver {
+	return res.getProfile(func(height float64) float64 {
+		return res.env.GetLightOnHeight(height, res.iteration)
+	})
+}
+func (res EnvironmentResolver) ToxicityProfile() []EnvironmentSampleResolver {
+	return res.getProfile(res.env.GetToxicityOnHeight)
+}
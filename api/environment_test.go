@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+func TestEnvironmentResolver(t *testing.T) {
+	r, _ := getTestRegistry(t)
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := `query GetEnvironment($iteration: Int, $samples: Int) {
+		environment(iteration: $iteration, samples: $samples) {
+			height
+			hour
+			lightProfile {
+				height
+				value
+			}
+			toxicityProfile {
+				height
+				value
+			}
+		}
+	}`
+
+	t.Run("samples profiles at future iteration", func(t *testing.T) {
+		// When
+		res := schema.Exec(
+			context.TODO(),
+			query,
+			"GetEnvironment",
+			map[string]interface{}{"iteration": 125, "samples": 5},
+		)
+
+		// Then
+		if len(res.Errors) > 0 {
+			t.Fatal(res.Errors)
+		}
+
+		var data struct {
+			Environment struct {
+				Height          float64
+				Hour            int
+				LightProfile    []EnvironmentSampleResolver
+				ToxicityProfile []EnvironmentSampleResolver
+			}
+		}
+		if err := json.Unmarshal(res.Data, &data); err != nil {
+			t.Fatal(err)
+		}
+
+		env := data.Environment
+		if env.Hour != 12 {
+			t.Errorf("Expected %d, got %d", 12, env.Hour)
+		}
+		if len(env.LightProfile) != 5 || len(env.ToxicityProfile) != 5 {
+			t.Fatalf("Expected %d samples, got %d", 5, len(env.LightProfile))
+		}
+		if env.LightProfile[4].Height != env.Height {
+			t.Errorf("Expected %f, got %f", env.Height, env.LightProfile[4].Height)
+		}
+		if env.ToxicityProfile[0].Value > env.ToxicityProfile[4].Value {
+			t.Error("Expected toxicity to grow with height")
+		}
+	})
+
+	t.Run("rejects too few samples", func(t *testing.T) {
+		// When
+		res := schema.Exec(
+			context.TODO(),
+			query,
+			"GetEnvironment",
+			map[string]interface{}{"samples": 1},
+		)
+
+		// Then
+		if len(res.Errors) == 0 {
+			t.Error("Expected error")
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// QueryHandler serves queries and mutations over HTTP, rejecting ones over
+// limits before they're executed
+type QueryHandler struct {
+	analyzer *costAnalyzer
+	limits   Limits
+	schema   *graphql.Schema
+}
+
+func writeResponse(w http.ResponseWriter, status int, response *graphql.Response) {
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseJSON)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeResponse(w, status, &graphql.Response{
+		Errors: []*gqlerrors.QueryError{{Message: err.Error()}},
+	})
+}
+
+// withTimeout limits context of queries and mutations, subscriptions last
+// until client stops them
+func (limits Limits) withTimeout(
+	ctx context.Context,
+	operation ast.Operation,
+) (context.Context, context.CancelFunc) {
+	if limits.Timeout <= 0 || operation == ast.Subscription {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, limits.Timeout)
+}
+
+func (h *QueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params wsOperation
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	operation, err := h.analyzer.check(
+		params.Query,
+		params.OperationName,
+		params.Variables,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := h.limits.withTimeout(r.Context(), operation)
+	defer cancel()
+
+	// Executor skips remaining fields once context is done and long
+	// resolvers check it themselves, so nothing runs after the deadline
+	response := h.schema.Exec(
+		ctx,
+		params.Query,
+		params.OperationName,
+		params.Variables,
+	)
+	if ctx.Err() != nil {
+		writeError(
+			w,
+			http.StatusServiceUnavailable,
+			fmt.Errorf("Request did not finish within %s", h.limits.Timeout),
+		)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
@@ -1,6 +1,9 @@
 package api
 
-import "github.com/golang/geo/r2"
+import (
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/golang/geo/r2"
+)
 
 type AreaInput struct {
 	Start r2.Point
@@ -8,10 +11,178 @@ type AreaInput struct {
 	Scale *int32
 }
 
+type IntRangeInput struct {
+	Min *int32
+	Max *int32
+}
+
+func (input *IntRangeInput) contains(value int) bool {
+	if input == nil {
+		return true
+	}
+
+	return (input.Min == nil || int(*input.Min) <= value) &&
+		(input.Max == nil || value <= int(*input.Max))
+}
+
 type OrganismFilter struct {
-	Area *AreaInput
+	Age     *IntRangeInput
+	Alive   *bool
+	Area    *AreaInput
+	Cells   *IntRangeInput
+	Diet    *[]string
+	Mass    *IntRangeInput
+	Species *[]int32
+}
+
+func hasAnyDiet(species sim.Species, diets []string) bool {
+	for _, diet := range getDietNames(species) {
+		for _, filterDiet := range diets {
+			if diet == filterDiet {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func hasSpecies(species sim.Species, ids []int32) bool {
+	for _, id := range ids {
+		if species.GetID() == int(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (filter OrganismFilter) apply(
+	organisms sim.OrganismList,
+	iteration int,
+) sim.OrganismList {
+	if filter.Area != nil {
+		organisms = organisms.GetArea(filter.Area.Start, filter.Area.End)
+		// Area used to return only alive organisms, so it still does by default
+		if filter.Alive == nil {
+			alive := true
+			filter.Alive = &alive
+		}
+	}
+
+	filtered := sim.OrganismList{}
+	for _, organism := range organisms {
+		if filter.Alive != nil && organism.IsAlive() != *filter.Alive {
+			continue
+		}
+		if !filter.Age.contains(iteration - organism.GetBornAt()) {
+			continue
+		}
+		if !filter.Cells.contains(len(organism.GetCells())) {
+			continue
+		}
+		if !filter.Mass.contains(organism.GetMass()) {
+			continue
+		}
+		if filter.Diet != nil && !hasAnyDiet(organism.GetSpecies(), *filter.Diet) {
+			continue
+		}
+		if filter.Species != nil && !hasSpecies(organism.GetSpecies(), *filter.Species) {
+			continue
+		}
+
+		filtered = append(filtered, organism)
+	}
+
+	return filtered
 }
 
 type SpeciesFilter struct {
 	Area *AreaInput
 }
+
+type SimulationConfigInput struct {
+	EnvDivisions       *int32
+	EnvHeight          *int32
+	EnvToxicity        *float64
+	EnvWidth           *int32
+	MaxCellsInOrganism *int32
+	MaxOrganisms       *int32
+	MutationRate       *float64
+	Seed               *string
+	StartCells         *int32
+	StepsPerSecond     *float64
+	WarmupIterations   *int32
+}
+
+func (input SimulationConfigInput) apply(config *sim.SimConfig) error {
+	if input.EnvDivisions != nil {
+		config.EnvDivisions = int(*input.EnvDivisions)
+	}
+	if input.EnvHeight != nil {
+		config.EnvHeight = int(*input.EnvHeight)
+	}
+	if input.EnvToxicity != nil {
+		config.EnvToxicity = *input.EnvToxicity
+	}
+	if input.EnvWidth != nil {
+		config.EnvWidth = int(*input.EnvWidth)
+	}
+	if input.MaxCellsInOrganism != nil {
+		config.MaxCellsInOrganism = int(*input.MaxCellsInOrganism)
+	}
+	if input.MaxOrganisms != nil {
+		config.MaxOrganisms = int(*input.MaxOrganisms)
+	}
+	if input.MutationRate != nil {
+		config.MutationRate = input.MutationRate
+	}
+	if input.Seed != nil {
+		seed, err := parseSeed(*input.Seed)
+		if err != nil {
+			return err
+		}
+		config.Seed = seed
+	}
+	if input.StartCells != nil {
+		config.StartCells = int(*input.StartCells)
+	}
+	if input.StepsPerSecond != nil {
+		config.StepsPerSecond = *input.StepsPerSecond
+	}
+	if input.WarmupIterations != nil {
+		config.WarmupIterations = int(*input.WarmupIterations)
+	}
+
+	return config.Validate()
+}
+
+type TuningInput struct {
+	EnvDivisions       *int32
+	MaxCellsInOrganism *int32
+	MaxOrganisms       *int32
+	StepsPerSecond     *float64
+	Verbose            *bool
+	WarmupIterations   *int32
+}
+
+func (input TuningInput) apply(config *sim.SimConfig) {
+	if input.EnvDivisions != nil {
+		config.EnvDivisions = int(*input.EnvDivisions)
+	}
+	if input.MaxCellsInOrganism != nil {
+		config.MaxCellsInOrganism = int(*input.MaxCellsInOrganism)
+	}
+	if input.MaxOrganisms != nil {
+		config.MaxOrganisms = int(*input.MaxOrganisms)
+	}
+	if input.StepsPerSecond != nil {
+		config.StepsPerSecond = *input.StepsPerSecond
+	}
+	if input.Verbose != nil {
+		config.Verbose = *input.Verbose
+	}
+	if input.WarmupIterations != nil {
+		config.WarmupIterations = int(*input.WarmupIterations)
+	}
+}
@@ -1,15 +1,16 @@
 package api
 
 import (
+	"github.com/dominik-zeglen/aquarium/analytics"
 	"github.com/dominik-zeglen/aquarium/sim"
 )
 
 type IterationWasteResolver struct {
 	w *sim.WasteData
-	s *sim.Sim
+	s *sim.Snapshot
 }
 
-func CreateIterationWasteResolver(data *sim.WasteData, sim *sim.Sim) IterationWasteResolver {
+func CreateIterationWasteResolver(data *sim.WasteData, sim *sim.Snapshot) IterationWasteResolver {
 	return IterationWasteResolver{data, sim}
 }
 
@@ -25,10 +26,10 @@ func (res IterationWasteResolver) Toxicity() float64 {
 
 type IterationProcreationResolver struct {
 	p *sim.ProcreationData
-	s *sim.Sim
+	s *sim.Snapshot
 }
 
-func CreateIterationProcreationResolver(data *sim.ProcreationData, sim *sim.Sim) IterationProcreationResolver {
+func CreateIterationProcreationResolver(data *sim.ProcreationData, sim *sim.Snapshot) IterationProcreationResolver {
 	return IterationProcreationResolver{data, sim}
 }
 
@@ -48,15 +49,15 @@ func (res IterationProcreationResolver) MinHeight() float64 {
 	return res.p.MinHeight
 }
 func (res IterationProcreationResolver) Species() []SpeciesResolver {
-	return createSpeciesResolverList(res.p.Species)
+	return createSpeciesResolverList(res.p.Species, res.s)
 }
 
 type IterationResolver struct {
 	d *sim.IterationData
-	s *sim.Sim
+	s *sim.Snapshot
 }
 
-func CreateIterationResolver(data *sim.IterationData, sim *sim.Sim) IterationResolver {
+func CreateIterationResolver(data *sim.IterationData, sim *sim.Snapshot) IterationResolver {
 	return IterationResolver{data, sim}
 }
 
@@ -64,6 +65,13 @@ func (res IterationResolver) AliveCellCount() int32 {
 	return int32(res.d.AliveCellCount)
 }
 
+func (res IterationResolver) Analytics() AnalyticsResolver {
+	return AnalyticsResolver{analytics.FromOrganisms(
+		res.s.GetIteration(),
+		res.s.GetOrganisms().GetAlive(),
+	)}
+}
+
 func (res IterationResolver) CellCount() int32 {
 	return int32(res.d.CellCount)
 }
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Limits of GraphQL requests, zero values disable limits
+type Limits struct {
+	// Maximum nesting of selected fields
+	MaxDepth int
+	// Maximum estimated number of resolved fields
+	MaxCost int
+	// Requests per second allowed for single client, up to RateBurst at once
+	Rate      float64
+	RateBurst int
+	// Maximum time of query or mutation, does not apply to subscriptions
+	Timeout time.Duration
+}
+
+var DefaultLimits = Limits{
+	MaxDepth:  12,
+	MaxCost:   50000,
+	Rate:      10,
+	RateBurst: 50,
+	Timeout:   10 * time.Second,
+}
+
+// Lists without "first" argument are assumed to have this many items
+const defaultListSize = 100
+
+// costAnalyzer parses queries on its own, because graphql-go keeps its AST
+// internal and only reports depth and cost after resolving every field
+type costAnalyzer struct {
+	schema *ast.Schema
+	limits Limits
+}
+
+func newCostAnalyzer(schemaStr string, limits Limits) (*costAnalyzer, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Input: schemaStr})
+	if err != nil {
+		return nil, err
+	}
+
+	return &costAnalyzer{schema, limits}, nil
+}
+
+// check returns type of requested operation or error if query is over budget
+func (a *costAnalyzer) check(
+	query string,
+	operationName string,
+	variables map[string]interface{},
+) (ast.Operation, error) {
+	doc, errs := gqlparser.LoadQuery(a.schema, query)
+	if len(errs) > 0 {
+		return "", errs
+	}
+
+	var operation *ast.OperationDefinition
+	if operationName == "" && len(doc.Operations) == 1 {
+		operation = doc.Operations[0]
+	} else {
+		operation = doc.Operations.ForName(operationName)
+	}
+	if operation == nil {
+		return "", fmt.Errorf("Operation %s not found", operationName)
+	}
+
+	depth := getDepth(operation.SelectionSet)
+	if a.limits.MaxDepth > 0 && depth > a.limits.MaxDepth {
+		return "", fmt.Errorf(
+			"Query depth %d exceeds limit of %d",
+			depth,
+			a.limits.MaxDepth,
+		)
+	}
+
+	cost := getCost(operation.SelectionSet, 1, 0, variables)
+	if a.limits.MaxCost > 0 && cost > float64(a.limits.MaxCost) {
+		return "", fmt.Errorf(
+			"Query cost %.0f exceeds limit of %d, select fewer fields or pass smaller first argument",
+			cost,
+			a.limits.MaxCost,
+		)
+	}
+
+	return operation.Operation, nil
+}
+
+// forEachField calls fn for fields selected directly or through fragments,
+// introspection fields are skipped
+func forEachField(selectionSet ast.SelectionSet, fn func(field *ast.Field)) {
+	for _, selection := range selectionSet {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !strings.HasPrefix(selection.Name, "__") {
+				fn(selection)
+			}
+		case *ast.InlineFragment:
+			forEachField(selection.SelectionSet, fn)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				forEachField(selection.Definition.SelectionSet, fn)
+			}
+		}
+	}
+}
+
+func getDepth(selectionSet ast.SelectionSet) int {
+	depth := 0
+	forEachField(selectionSet, func(field *ast.Field) {
+		if fieldDepth := getDepth(field.SelectionSet) + 1; fieldDepth > depth {
+			depth = fieldDepth
+		}
+	})
+
+	return depth
+}
+
+// getFirst returns value of field's "first" argument or 0 if it's not set
+func getFirst(field *ast.Field, variables map[string]interface{}) int {
+	argument := field.Arguments.ForName("first")
+	if argument == nil {
+		return 0
+	}
+
+	value, err := argument.Value.Value(variables)
+	if err != nil {
+		return 0
+	}
+
+	switch value := value.(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case json.Number:
+		first, _ := value.Int64()
+		return int(first)
+	}
+
+	return 0
+}
+
+// getCost estimates number of resolved fields. Every field costs 1 for each
+// parent list item. Connection's "first" argument sets size of its edges.
+func getCost(
+	selectionSet ast.SelectionSet,
+	multiplier float64,
+	pageSize int,
+	variables map[string]interface{},
+) float64 {
+	cost := float64(0)
+
+	forEachField(selectionSet, func(field *ast.Field) {
+		cost += multiplier
+
+		childMultiplier := multiplier
+		childPageSize := getFirst(field, variables)
+		if field.Definition != nil && field.Definition.Type.Elem != nil {
+			size := defaultListSize
+			if pageSize > 0 {
+				size = pageSize
+			}
+			if childPageSize > 0 {
+				size = childPageSize
+				childPageSize = 0
+			}
+			childMultiplier *= float64(size)
+		}
+
+		cost += getCost(field.SelectionSet, childMultiplier, childPageSize, variables)
+	})
+
+	return cost
+}
+
+type rateBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// rateLimiter is token bucket per client
+type rateLimiter struct {
+	burst   int
+	lock    sync.Mutex
+	rate    float64
+	buckets map[string]*rateBucket
+}
+
+// Buckets of idle clients are removed once there's this many of them
+const maxRateBuckets = 1e4
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		burst:   burst,
+		rate:    rate,
+		buckets: map[string]*rateBucket{},
+	}
+}
+
+func (l *rateLimiter) refill(bucket *rateBucket, now time.Time) {
+	bucket.tokens += now.Sub(bucket.updatedAt).Seconds() * l.rate
+	if bucket.tokens > float64(l.burst) {
+		bucket.tokens = float64(l.burst)
+	}
+	bucket.updatedAt = now
+}
+
+// allow returns time client has to wait before next request if it's over limit
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	if l == nil || l.rate <= 0 {
+		return true, 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if len(l.buckets) >= maxRateBuckets {
+		for id, bucket := range l.buckets {
+			if l.refill(bucket, now); bucket.tokens >= float64(l.burst) {
+				delete(l.buckets, id)
+			}
+		}
+	}
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &rateBucket{float64(l.burst), now}
+		l.buckets[client] = bucket
+	}
+	l.refill(bucket, now)
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	bucket.tokens--
+
+	return true, 0
+}
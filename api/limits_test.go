@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+func getTestAnalyzer(t *testing.T, limits Limits) *costAnalyzer {
+	schemaStr, err := GetSchemaStr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer, err := newCostAnalyzer(*schemaStr, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return analyzer
+}
+
+func TestLimits(t *testing.T) {
+	t.Run("rejects query walking all organisms of all species", func(t *testing.T) {
+		// Given
+		analyzer := getTestAnalyzer(t, DefaultLimits)
+
+		// When
+		_, err := analyzer.check(`{
+			speciesList {
+				organisms {
+					cells {
+						type {
+							id
+						}
+					}
+				}
+			}
+		}`, "", nil)
+
+		// Then
+		if err == nil || !strings.Contains(err.Error(), "cost") {
+			t.Errorf("Expected cost error, got %v", err)
+		}
+	})
+
+	t.Run("counts connection edges by first argument", func(t *testing.T) {
+		// Given
+		analyzer := getTestAnalyzer(t, Limits{MaxCost: 300})
+		query := `query List($first: Int) {
+			organismList(first: $first) {
+				edges {
+					node {
+						id
+						bornAt
+					}
+				}
+			}
+		}`
+
+		// When
+		_, smallErr := analyzer.check(query, "List", map[string]interface{}{"first": 10})
+		_, largeErr := analyzer.check(query, "List", map[string]interface{}{"first": 1000})
+
+		// Then
+		if smallErr != nil {
+			t.Error(smallErr)
+		}
+		if largeErr == nil {
+			t.Error("Expected large page to be rejected")
+		}
+	})
+
+	t.Run("rejects deep query", func(t *testing.T) {
+		// Given
+		analyzer := getTestAnalyzer(t, Limits{MaxDepth: 3})
+
+		// When
+		_, err := analyzer.check(`{
+			organism(id: 0) {
+				species {
+					organisms {
+						id
+					}
+				}
+			}
+		}`, "", nil)
+
+		// Then
+		if err == nil || !strings.Contains(err.Error(), "depth 4") {
+			t.Errorf("Expected depth error, got %v", err)
+		}
+	})
+
+	t.Run("limits requests of single client", func(t *testing.T) {
+		// Given
+		limiter := newRateLimiter(1, 2)
+
+		// When
+		first, _ := limiter.allow("a")
+		second, _ := limiter.allow("a")
+		third, wait := limiter.allow("a")
+		other, _ := limiter.allow("b")
+
+		// Then
+		if !first || !second || third || !other {
+			t.Errorf("Expected only third request of client to be rejected")
+		}
+		if wait <= 0 || wait > time.Second {
+			t.Errorf("Expected to wait up to a second, got %s", wait)
+		}
+	})
+
+	t.Run("responds with error over HTTP", func(t *testing.T) {
+		// Given
+		r, _ := getTestRegistry(t)
+		handler := InitAPI(r, timelapse.NewStore(), nil, Limits{MaxDepth: 1})
+		body, _ := json.Marshal(wsOperation{Query: `{ iteration { number } }`})
+		recorder := httptest.NewRecorder()
+
+		// When
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/api", bytes.NewReader(body)))
+
+		// Then
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected %d, got %d", http.StatusBadRequest, recorder.Code)
+		}
+		if !strings.Contains(recorder.Body.String(), "Query depth 2 exceeds limit of 1") {
+			t.Errorf("Expected depth error, got %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("stops resolving after timeout", func(t *testing.T) {
+		// Given
+		r, _ := getTestRegistry(t)
+		handler := InitAPI(r, timelapse.NewStore(), nil, Limits{Timeout: time.Nanosecond})
+		body, _ := json.Marshal(wsOperation{Query: `{ miniMap { diets } }`})
+		recorder := httptest.NewRecorder()
+
+		// When
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/api", bytes.NewReader(body)))
+
+		// Then
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+		}
+	})
+}
@@ -1,15 +1,22 @@
 package api
 
 import (
+	"fmt"
 	"log"
+	"net/http"
+	"strconv"
 
+	"github.com/gorilla/websocket"
 	graphql "github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/dominik-zeglen/aquarium/api/schema"
-	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/timelapse"
 )
 
+var errRateLimit = fmt.Errorf("Too many requests, slow down")
+
 func GetSchemaStr() (*string, error) {
 	schemaData, err := schema.Asset("api/schema/schema.graphql")
 	if err != nil {
@@ -21,23 +28,69 @@ func GetSchemaStr() (*string, error) {
 	return &schemaStr, nil
 }
 
-func GetSchema(sim *sim.Sim, iteration *sim.IterationData) (*graphql.Schema, error) {
+type Resolver struct {
+	*Query
+	*Mutation
+	*Subscription
+}
+
+func GetSchema(
+	r *registry.Registry,
+	timelapses *timelapse.Store,
+) (*graphql.Schema, error) {
 	schemaStr, err := GetSchemaStr()
 	if err != nil {
 		return nil, err
 	}
 
 	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
-	schema := graphql.MustParseSchema(*schemaStr, &Query{sim, iteration}, opts...)
+	resolver := &Resolver{
+		&Query{r, timelapses},
+		&Mutation{r, timelapses},
+		&Subscription{r},
+	}
+	schema := graphql.MustParseSchema(*schemaStr, resolver, opts...)
 
 	return schema, nil
 }
 
-func InitAPI(sim *sim.Sim, iteration *sim.IterationData) *relay.Handler {
-	schema, err := GetSchema(sim, iteration)
+// InitAPI serves queries over HTTP and subscriptions over WebSocket on the
+// same endpoint
+func InitAPI(
+	r *registry.Registry,
+	timelapses *timelapse.Store,
+	checkOrigin func(r *http.Request) bool,
+	limits Limits,
+) http.Handler {
+	schema, err := GetSchema(r, timelapses)
+	if err != nil {
+		log.Fatal(err)
+	}
+	schemaStr, err := GetSchemaStr()
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyzer, err := newCostAnalyzer(*schemaStr, limits)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	return &relay.Handler{Schema: schema}
+	limiter := newRateLimiter(limits.Rate, limits.RateBurst)
+	httpHandler := &QueryHandler{analyzer, limits, schema}
+	wsHandler := NewWebSocketHandler(schema, checkOrigin, analyzer, limiter)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := limiter.allow(middleware.GetClientID(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, errRateLimit)
+			return
+		}
+
+		if websocket.IsWebSocketUpgrade(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		httpHandler.ServeHTTP(w, r)
+	})
 }
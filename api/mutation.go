@@ -0,0 +1,348 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/metrics"
+	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+	"github.com/golang/geo/r2"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type Mutation struct {
+	registry   *registry.Registry
+	timelapses *timelapse.Store
+}
+
+type CreateSimulationArgs struct {
+	ID     graphql.ID
+	Config *SimulationConfigInput
+	Start  *bool
+}
+
+func (m *Mutation) CreateSimulation(
+	ctx context.Context,
+	args CreateSimulationArgs,
+) (*SimulationResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	config := m.registry.GetDefaultConfig()
+	if args.Config != nil {
+		if err := args.Config.apply(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	simulation, err := m.registry.Create(string(args.ID), config)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Start != nil && *args.Start {
+		if err := simulation.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SimulationResolver{simulation}, nil
+}
+
+type SimulationIDArgs struct {
+	ID graphql.ID
+}
+
+func (m *Mutation) StartSimulation(
+	ctx context.Context,
+	args SimulationIDArgs,
+) (*SimulationResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	simulation, err := m.registry.Start(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResolver{simulation}, nil
+}
+
+func (m *Mutation) StopSimulation(
+	ctx context.Context,
+	args SimulationIDArgs,
+) (*SimulationResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	simulation, err := m.registry.Stop(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResolver{simulation}, nil
+}
+
+func (m *Mutation) DeleteSimulation(
+	ctx context.Context,
+	args SimulationIDArgs,
+) (graphql.ID, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return args.ID, err
+	}
+
+	return args.ID, m.registry.Delete(string(args.ID))
+}
+
+type TuneSimulationArgs struct {
+	ID    graphql.ID
+	Input TuningInput
+}
+
+func (m *Mutation) TuneSimulation(
+	ctx context.Context,
+	args TuneSimulationArgs,
+) (*SimulationResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	simulation, err := m.registry.Get(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = exec(ctx, m.registry, &args.ID, func(s *sim.Sim) error {
+		config := s.GetConfig()
+		args.Input.apply(&config)
+
+		changes, err := s.Tune(config)
+		for _, change := range changes {
+			log.Printf("Simulation %s tuned, %s", args.ID, change)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResolver{simulation}, nil
+}
+
+// exec applies command to simulation between steps and returns snapshot
+// including its changes
+func exec(
+	ctx context.Context,
+	r *registry.Registry,
+	id *graphql.ID,
+	command sim.Command,
+) (*sim.Snapshot, error) {
+	simulation, err := r.Get(getSimulationID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.ObserveCommandWait(time.Since(start))
+	}()
+
+	return simulation.Sim.Exec(ctx, command)
+}
+
+// findOrganisms looks up organisms changed by command in the snapshot
+func findOrganisms(s *sim.Snapshot, organisms sim.OrganismList) []OrganismResolver {
+	resolvers := make([]OrganismResolver, 0, len(organisms))
+	for _, organism := range organisms {
+		if resolver := findOrganism(s, organism.GetID()); resolver != nil {
+			resolvers = append(resolvers, *resolver)
+		}
+	}
+
+	return resolvers
+}
+
+type SpawnOrganismArgs struct {
+	Species    int32
+	Position   r2.Point
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) SpawnOrganism(
+	ctx context.Context,
+	args SpawnOrganismArgs,
+) (*OrganismResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	var organism sim.Organism
+	s, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) (err error) {
+		organism, err = s.SpawnOrganism(int(args.Species), args.Position)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findOrganism(s, organism.GetID()), nil
+}
+
+func (m *Mutation) KillOrganism(
+	ctx context.Context,
+	args OrganismArgs,
+) (*OrganismResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	s, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) error {
+		_, err := s.KillOrganism(int(args.ID))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findOrganism(s, int(args.ID)), nil
+}
+
+type AreaArgs struct {
+	Area       AreaInput
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) KillArea(
+	ctx context.Context,
+	args AreaArgs,
+) ([]OrganismResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	var organisms sim.OrganismList
+	s, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) error {
+		organisms = s.KillArea(args.Area.Start, args.Area.End)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findOrganisms(s, organisms), nil
+}
+
+type ToxicityArgs struct {
+	Value      float64
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) SetToxicity(
+	ctx context.Context,
+	args ToxicityArgs,
+) (float64, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return 0, err
+	}
+
+	var toxicity float64
+	_, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) error {
+		toxicity = s.SetToxicity(args.Value)
+		return nil
+	})
+
+	return toxicity, err
+}
+
+func (m *Mutation) AddToxicity(
+	ctx context.Context,
+	args ToxicityArgs,
+) (float64, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return 0, err
+	}
+
+	var toxicity float64
+	_, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) error {
+		toxicity = s.AddToxicity(args.Value)
+		return nil
+	})
+
+	return toxicity, err
+}
+
+type CloneSpeciesArgs struct {
+	ID         int32
+	Area       AreaInput
+	Count      int32
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) CloneSpecies(
+	ctx context.Context,
+	args CloneSpeciesArgs,
+) (*SpeciesResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	var species sim.Species
+	s, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) (err error) {
+		species, err = s.CloneSpecies(
+			int(args.ID),
+			args.Area.Start,
+			args.Area.End,
+			int(args.Count),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findSpecies(s, species.GetID()), nil
+}
+
+type ReseedArgs struct {
+	Count      int32
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) Reseed(
+	ctx context.Context,
+	args ReseedArgs,
+) ([]OrganismResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	var organisms sim.OrganismList
+	s, err := exec(ctx, m.registry, args.Simulation, func(s *sim.Sim) (err error) {
+		organisms, err = s.Reseed(int(args.Count))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findOrganisms(s, organisms), nil
+}
+
+func parseSeed(seed string) (int64, error) {
+	value, err := strconv.ParseInt(seed, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Seed must be an integer, got %s", seed)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+func TestInterventionMutations(t *testing.T) {
+	// Given
+	r, s := getTestRegistry(t)
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	res := schema.Exec(
+		context.TODO(),
+		`mutation Intervene {
+			reseed(count: 3) {
+				id
+				bornAt
+			}
+			killOrganism(id: 0) {
+				id
+				cells {
+					alive
+				}
+			}
+			setToxicity(value: 2.5)
+		}`,
+		"Intervene",
+		map[string]interface{}{},
+	)
+
+	// Then
+	if len(res.Errors) > 0 {
+		t.Fatal(res.Errors)
+	}
+
+	var data struct {
+		Reseed       []struct{ ID int }
+		KillOrganism struct {
+			Cells []struct{ Alive bool }
+		}
+		SetToxicity float64
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Reseed) != 3 {
+		t.Errorf("Expected %d, got %d", 3, len(data.Reseed))
+	}
+	for _, cell := range data.KillOrganism.Cells {
+		if cell.Alive {
+			t.Error("Expected killed organism to have no alive cells")
+		}
+	}
+	if toxicity := s.GetEnvironment().GetToxicity(); toxicity != 2.5 {
+		t.Errorf("Expected %f, got %f", 2.5, toxicity)
+	}
+}
+
+func TestMutationRoles(t *testing.T) {
+	// Given
+	r, s := getTestRegistry(t)
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(
+		context.TODO(),
+		middleware.RoleContextKey,
+		middleware.RoleReader,
+	)
+
+	// When
+	res := schema.Exec(
+		ctx,
+		`mutation { setToxicity(value: 2.5) }`,
+		"",
+		map[string]interface{}{},
+	)
+
+	// Then
+	if len(res.Errors) == 0 {
+		t.Error("Expected reader not to be allowed to change toxicity")
+	}
+	if toxicity := s.GetEnvironment().GetToxicity(); toxicity == 2.5 {
+		t.Error("Expected toxicity not to change")
+	}
+}
+
+func TestTuneSimulation(t *testing.T) {
+	// Given
+	r, s := getTestRegistry(t)
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	res := schema.Exec(
+		context.TODO(),
+		`mutation {
+			tuneSimulation(id: "default", input: { maxOrganisms: 50, stepsPerSecond: 4 }) {
+				config {
+					maxOrganisms
+					stepsPerSecond
+				}
+			}
+		}`,
+		"",
+		map[string]interface{}{},
+	)
+
+	// Then
+	if len(res.Errors) > 0 {
+		t.Fatal(res.Errors)
+	}
+
+	var data struct {
+		TuneSimulation struct {
+			Config struct {
+				MaxOrganisms   int
+				StepsPerSecond float64
+			}
+		}
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.TuneSimulation.Config.MaxOrganisms != 50 {
+		t.Errorf("Expected %d, got %d", 50, data.TuneSimulation.Config.MaxOrganisms)
+	}
+	if data.TuneSimulation.Config.StepsPerSecond != 4 {
+		t.Errorf("Expected %f, got %f", 4., data.TuneSimulation.Config.StepsPerSecond)
+	}
+	if maxOrganisms := s.GetConfig().MaxOrganisms; maxOrganisms != 50 {
+		t.Errorf("Expected %d, got %d", 50, maxOrganisms)
+	}
+}
@@ -7,13 +7,17 @@ import (
 
 type OrganismResolver struct {
 	organism sim.Organism
+	s        *sim.Snapshot
 }
 
-func createOrganismResolverList(organisms sim.OrganismList) []OrganismResolver {
+func createOrganismResolverList(
+	organisms sim.OrganismList,
+	s *sim.Snapshot,
+) []OrganismResolver {
 	resolvers := make([]OrganismResolver, len(organisms))
 
 	for speciesIndex := range organisms {
-		resolvers[speciesIndex] = OrganismResolver{organisms[speciesIndex]}
+		resolvers[speciesIndex] = OrganismResolver{organisms[speciesIndex], s}
 	}
 
 	return resolvers
@@ -24,7 +28,23 @@ func (res OrganismResolver) ID() int32 {
 }
 
 func (res OrganismResolver) Species() SpeciesResolver {
-	return SpeciesResolver{res.organism.GetSpecies()}
+	return SpeciesResolver{res.organism.GetSpecies(), res.s}
+}
+
+func (res OrganismResolver) Action() string {
+	return string(res.organism.GetAction())
+}
+
+func (res OrganismResolver) Age() int32 {
+	return int32(res.s.GetIteration() - res.organism.GetBornAt())
+}
+
+func (res OrganismResolver) Alive() bool {
+	return res.organism.IsAlive()
+}
+
+func (res OrganismResolver) Angle() float64 {
+	return res.organism.GetAngle()
 }
 
 func (res OrganismResolver) BornAt() int32 {
@@ -32,9 +52,39 @@ func (res OrganismResolver) BornAt() int32 {
 }
 
 func (res OrganismResolver) Cells() []CellResolver {
-	return createCellResolverList(res.organism.GetCells())
+	return createCellResolverList(res.organism.GetCells(), res.s.GetIteration())
+}
+
+func (res OrganismResolver) DeathCause() *string {
+	if res.organism.IsAlive() {
+		return nil
+	}
+
+	cause := string(res.organism.GetDeathCause())
+	return &cause
+}
+
+func (res OrganismResolver) DiedAt() *int32 {
+	if res.organism.IsAlive() {
+		return nil
+	}
+
+	diedAt := int32(res.organism.GetDiedAt())
+	return &diedAt
+}
+
+func (res OrganismResolver) Mass() int32 {
+	return int32(res.organism.GetMass())
+}
+
+func (res OrganismResolver) Mobility() int32 {
+	return int32(res.organism.GetMobility())
 }
 
 func (res OrganismResolver) Position() r2.Point {
 	return res.organism.GetPosition()
 }
+
+func (res OrganismResolver) Target() r2.Point {
+	return res.organism.GetTarget()
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+func TestOrganismResolver(t *testing.T) {
+	// Given
+	r, s := getTestRegistry(t)
+	runStep(s)
+	id := s.GetSnapshot().GetOrganisms()[0].GetID()
+	s.Exec(context.TODO(), func(s *sim.Sim) error {
+		_, err := s.KillOrganism(id)
+		return err
+	})
+	schema, err := GetSchema(r, timelapse.NewStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	res := schema.Exec(
+		context.TODO(),
+		`query GetOrganism($id: Int!) {
+			organism(id: $id) {
+				action
+				age
+				alive
+				angle
+				deathCause
+				diedAt
+				mass
+				mobility
+				target {
+					x
+					y
+				}
+				species {
+					count
+					points
+					produces {
+						cellType
+						produces
+					}
+				}
+			}
+		}`,
+		"GetOrganism",
+		map[string]interface{}{"id": id},
+	)
+
+	// Then
+	if len(res.Errors) > 0 {
+		t.Fatal(res.Errors)
+	}
+
+	var data struct {
+		Organism struct {
+			Age        int
+			Alive      bool
+			DeathCause *string
+			DiedAt     *int
+			Mass       int
+			Species    struct {
+				Produces []struct{ Produces []int }
+			}
+		}
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+
+	organism := data.Organism
+	if organism.Alive || organism.DeathCause == nil || *organism.DeathCause != "intervention" {
+		t.Errorf("Expected organism killed by intervention, got %v", organism.DeathCause)
+	}
+	if organism.DiedAt == nil || *organism.DiedAt != 1 {
+		t.Errorf("Expected to die at %d, got %v", 1, organism.DiedAt)
+	}
+	if organism.Age != 1 {
+		t.Errorf("Expected %d, got %d", 1, organism.Age)
+	}
+	if organism.Mass == 0 {
+		t.Error("Expected organism to have mass")
+	}
+	if len(organism.Species.Produces) == 0 {
+		t.Error("Expected species to produce cells")
+	}
+}
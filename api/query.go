@@ -2,109 +2,179 @@ package api
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/dominik-zeglen/aquarium/registry"
 	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
 	"github.com/golang/geo/r2"
+	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/opentracing/opentracing-go"
 )
 
 type Query struct {
-	s         *sim.Sim
-	iteration *sim.IterationData
+	registry   *registry.Registry
+	timelapses *timelapse.Store
 }
 
-type OrganismArgs struct {
-	ID int32
+func getSimulationID(id *graphql.ID) string {
+	if id == nil {
+		return registry.DefaultID
+	}
+
+	return string(*id)
 }
 
-func (q *Query) Organism(args OrganismArgs) *OrganismResolver {
-	organisms := q.s.GetOrganisms()
-	id := int(args.ID)
+// getSnapshot returns state of simulation published after the last step, it's
+// read without locking sim
+func getSnapshot(r *registry.Registry, id *graphql.ID) (*sim.Snapshot, error) {
+	simulation, err := r.Get(getSimulationID(id))
+	if err != nil {
+		return nil, err
+	}
 
-	for _, cell := range organisms {
-		if cell.GetID() == id {
-			resolver := OrganismResolver{cell}
-			return &resolver
+	return simulation.Sim.GetSnapshot(), nil
+}
+
+func findOrganism(s *sim.Snapshot, id int) *OrganismResolver {
+	for _, organism := range s.GetOrganisms() {
+		if organism.GetID() == id {
+			return &OrganismResolver{organism, s}
 		}
 	}
 
 	return nil
+}
 
+func findSpecies(s *sim.Snapshot, id int) *SpeciesResolver {
+	for _, species := range s.GetSpecies().GetAlive() {
+		if species.GetID() == id {
+			return &SpeciesResolver{species, s}
+		}
+	}
+
+	return nil
+}
+
+type OrganismArgs struct {
+	ID         int32
+	Simulation *graphql.ID
+}
+
+func (q *Query) Organism(
+	ctx context.Context,
+	args OrganismArgs,
+) (*OrganismResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return nil, err
+	}
+
+	return findOrganism(s, int(args.ID)), nil
 }
 
 type OrganismListArgs struct {
-	Filter *OrganismFilter
+	Filter     *OrganismFilter
+	Sort       *OrganismSortInput
+	First      *int32
+	After      *string
+	Simulation *graphql.ID
 }
 
-func (q *Query) OrganismList(args OrganismListArgs) []OrganismResolver {
-	var organisms sim.OrganismList
+func (q *Query) OrganismList(
+	ctx context.Context,
+	args OrganismListArgs,
+) (OrganismConnectionResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return OrganismConnectionResolver{}, err
+	}
+
+	organisms := s.GetOrganisms()
 
-	if args.Filter != nil && args.Filter.Area != nil {
-		organisms = q.s.GetOrganisms().GetAlive().GetArea(args.Filter.Area.Start, args.Filter.Area.End)
-	} else {
-		organisms = q.s.GetOrganisms()
+	if args.Filter != nil {
+		organisms = args.Filter.apply(organisms, s.GetIteration())
 	}
 
-	return createOrganismResolverList(organisms)
+	return createOrganismConnection(organisms, s, args.Sort, args.First, args.After)
 }
 
 type SpeciesArgs struct {
-	ID int32
+	ID         int32
+	Simulation *graphql.ID
 }
 
-func (q *Query) Species(args SpeciesArgs) *SpeciesResolver {
-	species := q.s.GetSpecies().GetAlive()
-	id := int(args.ID)
-
-	for _, species := range species {
-		if species.GetID() == id {
-			resolver := SpeciesResolver{species}
-			return &resolver
-		}
+func (q *Query) Species(
+	ctx context.Context,
+	args SpeciesArgs,
+) (*SpeciesResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return findSpecies(s, int(args.ID)), nil
+}
+
+type SimulationArgs struct {
+	Simulation *graphql.ID
 }
 
-func (q *Query) SpeciesList() []SpeciesResolver {
-	species := q.s.GetSpecies().GetAlive()
+func (q *Query) SpeciesList(
+	ctx context.Context,
+	args SimulationArgs,
+) ([]SpeciesResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return nil, err
+	}
 
-	return createSpeciesResolverList(species)
+	return createSpeciesResolverList(s.GetSpecies().GetAlive(), s), nil
 }
 
 type SpeciesGridArgs struct {
-	Area AreaInput
+	Area       AreaInput
+	Simulation *graphql.ID
 }
 
 func (q *Query) SpeciesGrid(
 	ctx context.Context,
 	args SpeciesGridArgs,
-) []SpeciesGridElementResolver {
+) ([]SpeciesGridElementResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return nil, err
+	}
+
 	scale := int32(1)
 	if args.Area.Scale != nil {
 		scale = *args.Area.Scale
 	}
 
 	getOrganismsSpan, _ := opentracing.StartSpanFromContext(ctx, "get-organisms")
-	organisms := q.s.GetOrganisms().GetAlive().GetArea(args.Area.Start, args.Area.End)
+	organisms := s.GetOrganisms().GetAlive().GetArea(args.Area.Start, args.Area.End)
 	getOrganismsSpan.Finish()
 
 	getGridSpan, _ := opentracing.StartSpanFromContext(ctx, "get-grid")
-	grid := q.s.GetSpecies().GetAlive().GetArea(organisms, int(scale))
+	grid := s.GetSpecies().GetAlive().GetArea(organisms, int(scale))
 	getGridSpan.Finish()
 
 	resolvers := []SpeciesGridElementResolver{}
 
 	for y := range grid {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for x := range grid[y] {
 			resolvers = append(resolvers, CreateSpeciesGridElementResolver(
 				r2.Point{X: float64(x), Y: float64(y)},
 				grid[y][x],
+				s,
 			))
 		}
 	}
 
-	return resolvers
+	return resolvers, nil
 }
 
 type MiniMapPixelResolver struct {
@@ -112,19 +182,30 @@ type MiniMapPixelResolver struct {
 	Diets    []string
 }
 
-func (q *Query) MiniMap(ctx context.Context) []MiniMapPixelResolver {
+func (q *Query) MiniMap(
+	ctx context.Context,
+	args SimulationArgs,
+) ([]MiniMapPixelResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return nil, err
+	}
+
 	scale := int32(100)
 	getOrganismsSpan, _ := opentracing.StartSpanFromContext(ctx, "get-organisms")
-	organisms := q.s.GetOrganisms().GetAlive()
+	organisms := s.GetOrganisms().GetAlive()
 	getOrganismsSpan.Finish()
 
 	getGridSpan, _ := opentracing.StartSpanFromContext(ctx, "get-grid")
-	grid := q.s.GetSpecies().GetAlive().GetArea(organisms, int(scale))
+	grid := s.GetSpecies().GetAlive().GetArea(organisms, int(scale))
 	getGridSpan.Finish()
 
 	resolvers := []MiniMapPixelResolver{}
 
 	for y := range grid {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		for x := range grid[y] {
 			diets := []sim.Diet{}
 			position := r2.Point{X: float64(x), Y: float64(y)}
@@ -150,9 +231,84 @@ func (q *Query) MiniMap(ctx context.Context) []MiniMapPixelResolver {
 		}
 	}
 
-	return resolvers
+	return resolvers, nil
+}
+
+func (q *Query) Iteration(
+	ctx context.Context,
+	args SimulationArgs,
+) (IterationResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return IterationResolver{}, err
+	}
+	data := s.GetData()
+
+	return CreateIterationResolver(&data, s), nil
+}
+
+type EnvironmentArgs struct {
+	Iteration  *int32
+	Samples    *int32
+	Simulation *graphql.ID
+}
+
+func (q *Query) Environment(
+	ctx context.Context,
+	args EnvironmentArgs,
+) (EnvironmentResolver, error) {
+	s, err := getSnapshot(q.registry, args.Simulation)
+	if err != nil {
+		return EnvironmentResolver{}, err
+	}
+
+	res := EnvironmentResolver{
+		env:       s.GetEnvironment(),
+		iteration: s.GetIteration(),
+		samples:   defaultEnvironmentSamples,
+	}
+
+	if args.Iteration != nil {
+		if int(*args.Iteration) < res.iteration {
+			return res, fmt.Errorf(
+				"Iteration must not be in the past, current is %d",
+				res.iteration,
+			)
+		}
+		res.iteration = int(*args.Iteration)
+	}
+	if args.Samples != nil {
+		if *args.Samples < 2 || *args.Samples > maxEnvironmentSamples {
+			return res, fmt.Errorf(
+				"Samples must be between 2 and %d, got %d",
+				maxEnvironmentSamples,
+				*args.Samples,
+			)
+		}
+		res.samples = int(*args.Samples)
+	}
+
+	return res, nil
+}
+
+type SimulationByIDArgs struct {
+	ID *graphql.ID
+}
+
+func (q *Query) Simulation(args SimulationByIDArgs) *SimulationResolver {
+	id := registry.DefaultID
+	if args.ID != nil {
+		id = string(*args.ID)
+	}
+
+	simulation, err := q.registry.Get(id)
+	if err != nil {
+		return nil
+	}
+
+	return &SimulationResolver{simulation}
 }
 
-func (q *Query) Iteration() IterationResolver {
-	return CreateIterationResolver(q.iteration, q.s)
+func (q *Query) Simulations() []SimulationResolver {
+	return createSimulationResolverList(q.registry.List())
 }
@@ -34,7 +34,7 @@ func api_schema_schema_go() ([]byte, error) {
 	)
 }
 
-var _api_schema_schema_graphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x54\x4d\x6f\xdb\x30\x0c\xbd\xfb\x57\xa8\xb7\xf4\x2f\xe8\x96\x05\xeb\x16\x60\xc5\x32\xac\xc0\x0e\xc1\x0e\x8a\xcd\xc6\x04\xf4\xe1\x49\x74\xe7\x60\xe8\x7f\x1f\x24\x59\xb2\xfc\xd1\xdc\x64\x9a\x7c\x7c\xef\x91\x12\xea\xae\x27\x76\x32\xa8\xe9\x18\x8e\xff\x2a\xc6\x06\xce\x9e\xa4\x11\xf4\x50\x31\x76\xcb\xe7\xf7\xaa\x8a\xd9\x7b\x0b\x62\x4a\x76\x24\x2c\xf1\x02\xc2\x57\x81\x6e\x96\x21\x57\x0b\x09\x9c\x1d\x35\x4d\x48\xdf\xed\x55\x68\x74\xea\x09\x25\x81\x0d\x70\xc2\x82\xe0\x53\x0b\x9f\x4b\xb7\x0e\x22\xd8\x7d\x76\x21\xef\x48\x60\x05\xa1\xd1\x27\x6b\x6a\x0b\xe1\x18\xca\x6a\x91\x43\xc0\xd9\x27\x63\x24\x08\xed\x31\x94\x18\x0e\x4d\x20\x36\x7e\x7d\x05\xbc\xb6\x54\x74\x51\xa8\xcb\x0c\xd4\xab\x0c\xd7\x41\x8d\xe0\x38\x3b\xff\x8c\xa7\x87\xdf\x1b\x94\x7e\x09\x47\x10\xc8\x28\x31\xbc\x18\x09\x56\xe8\x1a\xe6\x8d\xb6\xc2\x64\x06\xac\x91\xee\x88\x8d\xd6\x49\x7c\x83\x03\x48\x79\x30\xbd\xa6\xcc\xb7\x5e\x45\x74\xaf\x2e\x60\xf3\x67\x37\x39\xc5\x37\xfd\xf3\x49\x7f\x3d\x77\xbe\xd0\x32\x31\x19\x65\x07\x1e\xd8\xcc\x7a\xbf\xdc\xba\xe0\xcc\x61\x3c\x7b\x6b\x18\x6b\x10\xc8\xdb\x45\x16\xf5\x35\x86\x40\x81\xbd\x42\xb3\x2f\x88\x0a\x05\x9c\x8d\x39\x15\x63\x66\xdc\x17\x0f\x97\x76\xa7\x74\x3a\xc5\x96\x34\x2e\xc6\xea\xfd\xdc\x91\xc4\x28\xb6\xee\x8c\xc3\xa8\x3f\xec\xd9\x6c\xa4\x69\xa2\xb9\x4b\x12\xb2\xec\xb2\x21\xe9\xb5\xd7\xd7\x16\x73\x46\x0b\xf6\x82\x6f\xc6\xc2\x18\x29\x11\x97\x68\x61\x9a\xb3\x45\x6d\xbb\x69\x64\x6b\xbe\x1e\x88\x67\x6e\xab\xc9\x7c\xb1\xd8\x7c\x96\xa0\x60\xbc\x45\xf7\x14\x6f\x2d\xf1\x33\x6a\x7c\x16\xdd\x09\x07\x90\x1f\x01\x78\x03\xdc\xcc\x81\x54\xfd\xa3\x07\x7b\x0b\x65\x69\x84\xbb\x24\xf5\x91\xe7\xb1\x15\xbf\xbf\xa1\xa3\xdd\x6b\x78\x16\xf8\xe2\x99\x78\x5c\x0c\x7f\x62\x5e\x62\x8e\x12\xa6\x9f\x1e\x71\x2e\x2d\xff\xf2\xde\xec\x16\x0f\x8f\xc7\x38\xaf\xbd\x8b\x75\x2a\x9a\xc1\xd9\xb9\xb4\x65\x24\x83\xe9\x8a\x14\xb7\x25\x38\xe1\xea\x16\x94\x08\x2e\xfc\xf1\x7e\xf0\x68\x4b\xf5\x5e\xfd\x0f\x00\x00\xff\xff\x24\xa9\xa0\x77\x86\x05\x00\x00")
+var _api_schema_schema_graphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x59\x5f\x8f\xdb\xb8\x11\x7f\xd7\xa7\x18\xdf\x3e\x74\x0f\x58\xa0\xef\x42\xaf\xc5\xc6\x76\x12\xa3\x77\xd9\x6d\xec\x5e\xda\x06\x79\xa0\xa5\xb1\x45\x44\x22\x55\x92\xb2\xd7\x17\xe4\xbb\x1f\xf8\x9f\x94\x64\x27\x79\x49\x4c\x6a\x38\x9c\xf9\x71\xe6\x37\x43\x2e\x65\xfd\xa0\xe0\x99\x53\xa6\x36\xe6\xe7\x97\x02\xe0\xa5\x84\xd7\x2d\x27\x6a\x51\x00\x5c\xc2\xef\xaf\x45\x61\xa5\x1f\x05\x92\x28\x2c\x15\x11\xaa\x4c\x54\xe8\x55\xc8\xea\xf1\x94\xac\x48\x8b\x25\x6c\x98\x8a\x9a\x36\x4c\xbd\x27\xec\x88\x51\x5b\x47\x99\x95\x01\xe8\xc8\x4b\x10\xbf\x83\x27\x71\x24\x8c\xca\x0e\x1a\x22\x41\x71\xe8\x88\xaa\x1a\xc0\x13\x8a\x0b\x48\x54\x70\xa0\xd8\xd6\x4e\xad\x97\x7d\x4d\x5b\x85\xc2\xe8\x25\x47\x2c\xf3\xed\x0a\x80\x3b\x58\xe1\x81\x0c\xad\x32\x1a\x95\x18\x10\xe8\x01\x88\x40\x02\x54\x6a\xad\x7a\x61\x4b\x4f\x58\xc2\x2b\xce\x5b\x24\x4c\x4f\x08\x24\x65\xc4\xa0\x00\xa8\xb0\x6d\xe5\x9c\xf6\x47\x76\x01\x7e\x00\xd9\x63\x45\x51\xfe\x05\x6a\x8a\x4a\x16\x60\xfe\x2f\xe1\xe3\x56\x09\xca\x8e\x8b\x4f\xc6\x57\x39\xa3\xc1\x2d\x2c\xe1\xe3\x86\xa9\xc5\x27\x0d\x04\xb2\xa1\x83\x2d\x17\x6a\x45\x05\x56\x8a\x72\x66\xdc\x7b\xdc\x2e\x0b\x80\xd5\x7a\xbb\x0c\x42\x1e\x04\x2d\xfc\x5a\x83\x63\x05\xdf\xac\x0b\x80\xcd\xaa\x00\xd8\x6e\xfe\xb7\x8e\x47\x91\x8a\xc7\xe3\x30\xa8\x96\x53\x5d\x0b\xe3\x85\xb3\xa0\x1c\x19\xf4\x8b\x31\xe7\x6b\x51\xa8\x4b\x8f\x36\x0a\x6e\x87\x95\x91\xdb\x28\x14\x44\xaf\x7f\x16\xbc\x12\x48\x82\x6f\x15\x09\x53\xf1\x20\x16\x36\x40\x96\xb5\x41\xcd\x8d\xde\x22\x3d\x36\x2a\xd9\xa5\xa3\x2c\x95\xa0\x6c\x22\x11\x21\xde\xda\x5f\x8b\x4f\x33\x26\x7d\x20\x52\xa1\x8d\x4f\xf2\xb2\xe3\x2d\x0a\xc2\x2a\xcc\x37\x9a\x9b\x56\xfc\x85\x56\x54\x4d\x9d\x5d\xd1\x13\x0a\x49\xd5\xc5\x28\xc5\x13\x32\x86\x52\x06\x31\x00\x41\xab\xc6\x4e\x39\xe3\x65\x43\x18\xe3\x2c\x11\x91\xb4\xeb\x25\x67\x13\xdd\x6f\xa9\x54\xfc\x28\x48\xf7\x8a\x3a\x00\xf9\xc0\x54\x50\x64\x52\x33\x2a\xb1\xd9\x3b\x52\xb1\x13\x84\xaa\xa0\xc7\x28\xd9\x53\xa6\x51\x4a\x95\x6b\xa8\x5c\x9a\x46\x20\x90\xb0\x1c\x97\x64\xa4\xb4\xda\x12\x5c\xdc\x27\x60\xa0\x7a\x45\xb9\xce\x01\xb7\x15\x0f\xf9\xb0\xc8\xf2\x6b\x11\x92\x67\xac\xe3\x91\x91\xf6\xa2\x68\x35\xd2\xf0\x31\xd1\x6d\xcd\xad\x3d\xf2\x65\x3c\x04\x3d\x4f\xfd\x59\x87\x8d\x8c\xb5\x5a\x47\x8e\xc6\x6c\x80\xc0\x17\x4f\x15\x4b\x6c\xdb\x65\x06\x38\xf1\xa6\x95\xd1\x4a\xef\x56\x2e\xc9\x86\x6e\x8f\x22\x0c\xfb\x98\x08\xe5\x6c\x7a\x68\xa1\xb3\x0e\xcd\x72\x14\xaa\x0b\xcb\x99\xda\x16\x30\x86\x9e\xa9\x6a\xe0\x48\x4f\xc8\x60\xb3\xd2\x19\xa5\x95\xd7\x43\x85\x50\x79\x19\x09\x2d\x95\x0a\x6b\xa0\xe1\xa3\xb4\x5e\x6a\x35\xbb\x4b\x8f\xcf\x66\x36\xe6\xa5\x9b\x4e\xed\x35\xab\x3c\x5f\x45\x9c\x5c\x6e\x99\x65\xb4\xce\x0e\x56\x6b\xd0\x2b\xfc\x26\xf6\x94\xf2\x90\x1d\xf1\xa5\x9e\xc2\x0e\xc5\x11\xeb\xc7\x28\xc4\x48\x87\x31\x2e\x00\xb8\xe3\x2c\xad\xdc\xf3\x97\x5d\xdb\x6b\x46\x92\x73\x66\x4f\x3d\x4d\xbd\xf0\x5a\xc6\x6e\x10\xcf\x82\x61\x6f\x5f\x6d\x16\x93\x02\x62\x66\xd8\xb1\x4d\x59\x62\xcf\x05\x7b\x54\x19\x2a\xde\x18\x17\xb3\x48\x54\xb3\x24\x83\x0c\x0e\x5a\x50\xbc\xfb\x69\x01\x31\x59\xc7\xf7\xb4\x35\x21\xee\x5d\xe4\x92\x5a\x1b\x0d\x1b\x67\xc4\xe7\x79\xaf\x00\x50\x44\x1c\x51\x05\xa1\xc0\xe0\x44\x97\xa4\x03\xb7\x5c\xc5\xea\xe5\x20\x24\x17\x89\x29\x0d\x91\xef\xf0\x45\x3d\x93\x63\xee\x68\x43\xe4\xb3\xc0\x13\xe5\x83\x9c\x7c\x33\xc4\x33\xd2\x34\x06\x7a\x5d\x1f\x2d\xeb\x56\x99\x9c\x39\x6d\x5e\x63\xac\x4b\xd3\x33\x5a\x72\xc6\x92\xfa\x88\xf5\x11\xd3\x40\xd0\x9a\x5d\x30\x38\xe7\xca\xe0\xa6\x25\x6e\x45\xb2\xe4\xf4\xfa\x7d\x84\x4c\x62\x40\x29\x52\x7d\x8e\x67\x48\x98\xb3\x20\x73\xba\x22\x82\xd1\x13\x17\x31\x3a\x2a\x2b\x25\xd3\x09\x39\x74\x7d\xc6\x44\x35\x1e\xd0\x14\x96\x1b\xf9\xc0\xfe\xb8\x74\x18\xd5\x1c\x38\xaf\x7f\x27\xed\x10\x17\x1d\x06\x76\x6c\x68\x18\x36\x28\xf6\xb9\x29\x79\x08\x91\x97\x25\xe9\x49\x95\x46\x91\xae\xb0\x7d\x3a\xda\x12\x45\x73\xce\xec\xb0\xdb\x0b\xc2\xf0\x56\x28\x8e\x93\xcf\xb3\x59\x52\xa9\x65\x43\xfa\x2c\x99\x25\xfd\x23\xea\x54\xb4\xc3\x1d\x5f\x51\x4c\xa9\x9a\xc9\x9e\x8b\x98\x44\x86\x16\xa7\x25\x39\x3d\xc6\xc9\x11\xde\x4c\xda\x71\x92\x8e\xc1\xc9\xd3\xb1\xe9\x6f\xe5\x9e\x77\x3a\xe3\x2f\x39\x01\x53\x19\x72\x0d\xc4\x38\x66\xd3\x37\x82\xd6\xeb\x16\x3b\x74\xed\xd5\xad\x24\x9f\xeb\x6e\x7e\xa3\x8c\xfe\x46\xfa\x67\xfa\x82\xed\x35\x05\xa6\x63\xcd\x42\xcd\xaf\x5e\xb3\x13\x15\x9c\xe9\xdd\xb7\xa4\xeb\x5b\x9b\x11\xcd\xb8\xbf\x3a\xd9\x18\x8c\xf0\xdf\xc1\xaf\x5a\x04\x6a\xec\x91\xd5\x12\x38\x83\x86\x0f\xc2\xf4\xc9\xb4\x1b\x5a\x8d\x0a\xd4\xe4\xf2\x00\xe7\x86\xb6\x18\x5a\x28\x0d\xda\x41\x4f\x90\xf6\x4c\x2e\x12\x06\x89\xb2\xb8\xd3\xcc\x20\x34\x00\x5e\x6c\x62\x5c\x66\x96\x8f\x7d\x3e\xc4\x2a\x3b\x2d\xfc\xad\x16\x7e\xb6\xdb\x95\xf0\x71\xe2\xa9\xcd\xb8\x49\x6f\x17\xa7\xbe\xb9\xf6\x4c\x6b\xd5\x8c\x98\x65\x6b\xbd\xd7\x89\xc0\xd9\x81\x1e\x1d\xdb\x9e\x56\xf4\x44\x25\xe5\x4c\x26\x1d\xdc\xe9\x6d\xee\x10\xb2\xd3\x6e\x6a\x0e\xb2\xd3\x87\x64\x23\x9b\xd4\xba\xb8\x6c\x98\xe7\xc1\xf4\xd3\x53\x2c\x98\x7e\x72\x50\xc6\xa0\xf7\xa6\xf3\x0e\x7a\x25\x62\x9d\x25\xa7\xa1\xf2\xac\x49\x93\x0a\x7b\xf9\x8c\x62\x8b\x15\xcf\xfa\xcd\x13\x8a\x3d\x97\x79\x6a\x9d\x89\xe8\x86\x3e\x74\x30\x32\x02\x63\xaf\x26\x63\x64\xe2\xf5\x64\x02\xcf\x04\x9d\x59\x70\xc6\xd8\x5c\x87\x66\x0e\x99\x79\x60\x46\xb8\x4c\x61\xb9\x86\xca\x35\x00\x6c\xb2\x3c\x13\x41\x3a\x54\x28\xa4\xce\x87\xaa\x31\xbd\xdb\x1e\xa1\x6a\xf4\x45\xb1\x76\x49\x22\x03\x42\x40\x25\x88\x81\x31\x6d\x82\x45\x6f\x37\xe8\xc1\x6d\xcc\x7e\xcc\xfb\x6b\x6e\x8c\xcf\xf6\x96\x67\xa3\x90\x8f\x5c\xbc\x72\x45\xf0\x40\x8f\xe5\xe4\xe4\xe7\xf3\xd5\xf9\x9b\xc4\xd4\xa4\x3f\xff\x77\x5f\x13\x77\x83\xbb\xd2\xa5\x7f\xb3\x1b\x77\x5c\x9a\xcb\x5c\xef\xbe\x53\xa6\x4e\x76\x4f\xea\xcd\x77\x75\xb5\x53\x6f\xf3\x3e\xd7\xef\xf4\x3b\xc5\xb3\x2e\x80\x57\x7b\xd4\x49\x41\x9b\xbb\x55\x65\xc6\xdc\xaa\x27\x19\x73\xf9\xbd\x53\x37\x27\x66\xa7\xed\xf8\xd8\x58\x57\x57\xcc\xb3\xc5\x92\xb7\x5c\x6c\xab\x06\x3b\x4c\x1e\x2c\x56\x9b\xf5\xae\x00\xd8\x3e\xaf\x97\x9b\xf5\xb6\x00\xd8\x3d\xfd\x67\xb3\xdc\xec\xfe\x1b\x59\xe2\x3d\xb2\x1a\x45\x8c\xf3\x3b\xf8\xd0\xf0\x16\xe1\xcc\x45\x5b\x9b\xb4\x30\x02\x58\x03\x3d\x00\xe3\xca\xbf\xef\x4c\x9e\x73\xce\x29\x2f\x34\x19\x95\x48\x63\x57\x99\x1a\x19\x91\xf4\x51\x1f\x2c\xda\xd1\x0e\x5b\xd2\x4b\x4c\x8d\x4a\x5f\x9c\x7c\xf1\x0a\x68\xc5\x0b\xb9\xa7\xae\x78\x82\xe6\xa9\xcb\x7f\xb8\x83\x7f\x22\xf6\xc0\x59\x7b\x01\xd5\x50\xe9\x82\x55\x57\x51\xd5\x20\xe8\x2a\x2a\x15\x1c\x34\x77\x48\xe3\x12\xab\xf9\x39\x2e\x5e\x61\x4b\x2e\xb0\x47\x75\x46\x64\x4e\x0c\x28\x83\x66\x60\xb5\xc0\x5a\x35\x52\x6b\x22\x20\x4d\x86\x9b\x0e\xb4\x25\x61\x73\x0b\x64\x99\x22\x1e\x8e\x2f\xf8\xbc\x55\x44\x0d\xf6\xc6\xf7\xe1\x71\xb3\xdb\xbc\x7b\x53\x00\xbc\x5f\x2f\x9f\xde\xaf\xec\xef\xd5\xd3\xbb\xb5\xe5\xb8\x37\x9b\xd7\xf6\xbd\x4d\x9c\xb0\x06\xa2\xe0\xaf\xca\x6b\xf9\x07\xad\x7f\xf9\x1b\xad\xff\xfe\xa0\xdd\xd7\xc4\x77\xe0\x02\x41\x60\xc5\x45\x4d\xd9\x51\x2f\xab\x39\x43\xf7\x6a\xe1\x57\x8d\xd8\x24\xb2\x63\x98\x31\xc6\x95\x63\x6b\xf5\x27\x0b\x46\x40\xfd\x2e\xe6\x76\x04\x57\x2a\xdd\xff\xa9\x41\x07\x93\x91\x9f\x8d\xf8\x78\x92\x8b\xab\x47\x69\x46\x42\xe4\x77\xa0\x3b\x58\x6b\x01\xf8\xff\xa0\xff\x25\x55\x85\xbd\x92\xc0\xcd\x9d\x80\xb4\x89\x3b\xb0\x59\x3d\xc0\x4f\xb5\x8d\xa8\x9f\x34\x18\x83\xcc\xe3\xdb\xe0\xf2\x2f\xa3\xe7\x4b\x92\x84\xf7\x9e\x19\x1e\x46\xe0\xfc\x1c\xef\x54\x89\xf8\xaf\x54\xaa\xfb\x02\x00\xe0\x60\x5e\x54\xcb\xd1\x0b\xab\xf9\x24\x4d\xfb\x3d\x79\x46\x74\xcb\x84\x0c\x41\x0d\x40\x0e\x0a\xa3\xc7\x66\x71\x66\x45\x01\x90\x18\x12\xaf\x72\x8b\x22\x72\xd0\x2d\x17\x1c\xed\x46\x61\xe3\xc0\x44\x2c\x6d\x8a\x83\xa8\xee\xaa\xef\x47\xa4\x30\xb7\xc7\xc7\x69\x17\x6e\xf5\x74\xb6\xad\x9e\xd9\x2e\x6d\xb8\xb5\x6c\x1a\x35\x53\xf1\x10\x77\x36\x0c\x5d\x4f\x29\x81\x08\x04\x69\x7a\x49\x93\x2b\x13\x0e\x01\x2e\xe0\x30\xe8\xe0\x04\xce\xcc\x83\xb6\x25\x3a\x8c\xad\xe8\x7d\x1e\xac\x0f\x4e\x9f\xf4\xa3\xb1\x29\x49\x17\x6b\x8f\x20\x08\xd8\x53\x30\x98\x87\xb9\x4c\xc0\x5c\x1e\xc2\xc8\x79\x1d\xd2\xdb\x2d\x5f\xfc\x9c\xa4\x62\xfa\xdd\xbc\xbe\xf9\x41\x76\x77\x71\x0d\x98\x7d\x1d\x30\x77\xa9\xb8\x8b\x0d\xd5\x98\xff\xd7\xfb\x89\x18\xa1\x2e\x59\x63\xe7\x92\xb9\x14\xd2\x79\x3b\xf6\x7c\x31\x23\xc7\xfb\xef\x10\xab\xb1\x45\x85\xb3\x82\xd6\xea\x3b\x58\x9a\x1e\xcf\x9e\x38\xe9\xfb\x96\x62\x1d\x38\xdb\xf4\x60\x0f\x20\x79\xac\x6f\x9f\xb1\xd7\xbe\xa8\x81\xcd\xa8\x7d\x00\x53\x93\xca\xb4\x1d\x1c\x1b\x65\xe9\x8e\x29\xcd\xc3\xcc\x1c\xde\xf5\xad\x4d\xc2\x90\x73\xe8\x17\x2d\xe6\x79\x7f\x00\x30\x69\x22\xc2\x5f\x83\x6e\xa7\xbc\x96\xf8\x4c\xdb\xf6\xe9\x07\xf8\xca\xaf\xd1\x59\xfb\x5d\x09\x9c\x3f\x0a\x4a\x54\xfe\x96\x70\x9f\x5d\x5a\x67\x96\x86\xdb\x0c\xa9\xeb\x1f\x5f\x54\xb5\x9c\xa1\x63\x8f\x24\x54\x3d\x62\x63\xd3\x5d\x00\xa7\x5d\xe7\x3c\x78\xc9\x43\x9e\x40\x7d\x17\xb9\x4f\x56\x7d\x0b\x80\x02\x5c\x55\xdd\xc5\xdc\x74\x01\x93\x75\x31\x73\x8a\x62\x86\xda\xea\x65\xfb\x40\xc7\x54\xc8\x94\xe5\x7b\xff\xb7\x3a\x85\xfd\x03\xc8\x96\x9f\x41\x0e\x7b\x59\x09\xba\x47\x21\xa1\xa3\x52\x82\xe4\x1d\xba\x2a\xdb\xb9\x06\xda\x8a\xf4\xf1\x8e\xe0\x99\x6b\xc9\x35\x65\x29\xac\x6f\x30\xa7\x35\x24\x21\xf7\xb5\xed\xaf\xef\xaf\x55\x8c\xe9\x82\x17\x45\x59\xa5\xbe\x63\xc1\x29\x6b\x82\xe5\xf7\x44\x60\xde\x37\x1b\xf0\x4c\x83\x49\x8c\xa7\xa6\xfa\x97\xb6\x78\x27\x57\xce\x32\x70\x9f\xb6\x32\x81\xa7\xcc\xc0\x2a\xbe\x16\x7f\x0e\x00\xb3\x94\xfe\x66\xdb\x1d\x00\x00")
 
 func api_schema_schema_graphql() ([]byte, error) {
 	return bindata_read(
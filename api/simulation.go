@@ -0,0 +1,81 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type SimulationConfigResolver struct {
+	config sim.SimConfig
+}
+
+func (res SimulationConfigResolver) EnvDivisions() int32 {
+	return int32(res.config.EnvDivisions)
+}
+func (res SimulationConfigResolver) EnvHeight() int32 {
+	return int32(res.config.EnvHeight)
+}
+func (res SimulationConfigResolver) EnvToxicity() float64 {
+	return res.config.EnvToxicity
+}
+func (res SimulationConfigResolver) EnvWidth() int32 {
+	return int32(res.config.EnvWidth)
+}
+func (res SimulationConfigResolver) MaxCellsInOrganism() int32 {
+	return int32(res.config.MaxCellsInOrganism)
+}
+func (res SimulationConfigResolver) MaxOrganisms() int32 {
+	return int32(res.config.MaxOrganisms)
+}
+func (res SimulationConfigResolver) MutationRate() float64 {
+	return *res.config.MutationRate
+}
+func (res SimulationConfigResolver) Seed() string {
+	return strconv.FormatInt(res.config.Seed, 10)
+}
+func (res SimulationConfigResolver) StartCells() int32 {
+	return int32(res.config.StartCells)
+}
+func (res SimulationConfigResolver) StepsPerSecond() float64 {
+	return res.config.StepsPerSecond
+}
+func (res SimulationConfigResolver) Verbose() bool {
+	return res.config.Verbose
+}
+func (res SimulationConfigResolver) WarmupIterations() int32 {
+	return int32(res.config.WarmupIterations)
+}
+
+// SimulationResolver does not lock sim, so many simulations can be listed in
+// one request
+type SimulationResolver struct {
+	simulation *registry.Simulation
+}
+
+func createSimulationResolverList(
+	simulations []*registry.Simulation,
+) []SimulationResolver {
+	resolvers := make([]SimulationResolver, len(simulations))
+
+	for simulationIndex := range simulations {
+		resolvers[simulationIndex] = SimulationResolver{simulations[simulationIndex]}
+	}
+
+	return resolvers
+}
+
+func (res SimulationResolver) ID() graphql.ID {
+	return graphql.ID(res.simulation.ID)
+}
+func (res SimulationResolver) Running() bool {
+	return res.simulation.IsRunning()
+}
+func (res SimulationResolver) Config() SimulationConfigResolver {
+	return SimulationConfigResolver{res.simulation.GetConfig()}
+}
+func (res SimulationResolver) Iteration() int32 {
+	return int32(res.simulation.GetIteration())
+}
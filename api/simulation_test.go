@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+func TestCreateSimulation(t *testing.T) {
+	configs := []string{
+		"{ envWidth: 3 }",
+		"{ startCells: -1 }",
+		"{ maxOrganisms: 0 }",
+		"{ stepsPerSecond: -1 }",
+	}
+
+	for _, config := range configs {
+		t.Run("rejects "+config, func(t *testing.T) {
+			// Given
+			r := registry.New(sim.SimConfig{
+				EnvDivisions:       4,
+				MaxCellsInOrganism: 25,
+				MaxOrganisms:       1e3,
+				StartCells:         10,
+			})
+			schema, err := GetSchema(r, timelapse.NewStore())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// When
+			res := schema.Exec(
+				context.TODO(),
+				`mutation { createSimulation(id: "invalid", config: `+config+`) { id } }`,
+				"",
+				map[string]interface{}{},
+			)
+
+			// Then
+			if len(res.Errors) == 0 {
+				t.Error("Expected error")
+			}
+			if _, err := r.Get("invalid"); err == nil {
+				t.Error("Simulation should not be created")
+			}
+		})
+	}
+}
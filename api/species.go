@@ -1,22 +1,34 @@
 package api
 
 import (
-	"context"
-
-	"github.com/dominik-zeglen/aquarium/middleware"
 	"github.com/dominik-zeglen/aquarium/sim"
 	"github.com/golang/geo/r2"
 )
 
+func getDietNames(species sim.Species) []string {
+	diets := species.GetDiets()
+	dietNames := make([]string, len(diets))
+
+	for dietIndex, diet := range diets {
+		dietNames[dietIndex] = diet.String()
+	}
+
+	return dietNames
+}
+
 type SpeciesResolver struct {
 	species sim.Species
+	s       *sim.Snapshot
 }
 
-func createSpeciesResolverList(species sim.SpeciesList) []SpeciesResolver {
+func createSpeciesResolverList(
+	species sim.SpeciesList,
+	s *sim.Snapshot,
+) []SpeciesResolver {
 	resolvers := make([]SpeciesResolver, len(species))
 
 	for speciesIndex := range species {
-		resolvers[speciesIndex] = SpeciesResolver{species[speciesIndex]}
+		resolvers[speciesIndex] = SpeciesResolver{species[speciesIndex], s}
 	}
 
 	return resolvers
@@ -34,43 +46,58 @@ func (res SpeciesResolver) EmergedAt() int32 {
 	return int32(res.species.GetEmergedAt())
 }
 func (res SpeciesResolver) Diet() []string {
-	diets := res.species.GetDiets()
-	dietNames := make([]string, len(diets))
-
-	for dietIndex, diet := range diets {
-		dietNames[dietIndex] = diet.String()
-	}
-
-	return dietNames
+	return getDietNames(res.species)
 }
-func (res SpeciesResolver) Organisms(ctx context.Context) []OrganismResolver {
-	s := ctx.Value(middleware.SimContextKey).(*sim.Sim)
-	organisms := s.GetOrganisms().GetSpecies(res.species.GetID())
+func (res SpeciesResolver) Organisms() []OrganismResolver {
+	organisms := res.s.GetOrganisms().GetSpecies(res.species.GetID())
 
-	return createOrganismResolverList(organisms)
+	return createOrganismResolverList(organisms, res.s)
 }
 func (res SpeciesResolver) CellTypes() []CellTypeResolver {
 	return createCellTypeResolverList(res.species.GetTypes())
 }
+func (res SpeciesResolver) Count() int32 {
+	return int32(res.species.GetCount())
+}
+func (res SpeciesResolver) Points() int32 {
+	return int32(res.species.GetPoints())
+}
+func (res SpeciesResolver) Produces() []CellTypeProductionResolver {
+	produces := res.species.GetProduces()
+	resolvers := make([]CellTypeProductionResolver, len(produces))
+
+	for cellTypeID, producedIDs := range produces {
+		resolvers[cellTypeID] = CellTypeProductionResolver{
+			CellType: int32(cellTypeID),
+			Produces: make([]int32, len(producedIDs)),
+		}
+		for producedIndex, producedID := range producedIDs {
+			resolvers[cellTypeID].Produces[producedIndex] = int32(producedID)
+		}
+	}
+
+	return resolvers
+}
+
+type CellTypeProductionResolver struct {
+	CellType int32
+	Produces []int32
+}
 
 type SpeciesGridElementResolver struct {
 	Position r2.Point
 	species  []sim.Species
+	s        *sim.Snapshot
 }
 
 func CreateSpeciesGridElementResolver(
 	position r2.Point,
 	species []sim.Species,
+	s *sim.Snapshot,
 ) SpeciesGridElementResolver {
-	return SpeciesGridElementResolver{position, species}
+	return SpeciesGridElementResolver{position, species, s}
 }
 
 func (res SpeciesGridElementResolver) Species() []SpeciesResolver {
-	resolvers := make([]SpeciesResolver, len(res.species))
-
-	for speciesIndex := range res.species {
-		resolvers[speciesIndex] = SpeciesResolver{res.species[speciesIndex]}
-	}
-
-	return resolvers
+	return createSpeciesResolverList(res.species, res.s)
 }
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/golang/geo/r2"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Updates waiting for slow subscribers are dropped once buffer is full
+const subscriptionBufferSize = 16
+
+// Subscription resolvers never hold sim lock between updates. Updates are
+// copied from sim by observers while sim is locked by RunLoop anyway, and
+// observers are added and removed with commands.
+type Subscription struct {
+	registry *registry.Registry
+}
+
+type IterationUpdateResolver struct {
+	AliveCellCount int32
+	CellCount      int32
+	Number         int32
+	SpeciesCount   int32
+	waste          sim.WasteData
+}
+
+func (res IterationUpdateResolver) Waste() IterationWasteResolver {
+	return CreateIterationWasteResolver(&res.waste, nil)
+}
+
+type SpeciesUpdateResolver struct {
+	Diet      []string
+	EmergedAt int32
+	ID        int32
+	Iteration int32
+	Name      string
+}
+
+type ViewportOrganismResolver struct {
+	Alive    bool
+	Cells    int32
+	Diet     []string
+	ID       int32
+	Position r2.Point
+	Species  int32
+}
+
+type ViewportUpdateResolver struct {
+	Iteration int32
+	Organisms []ViewportOrganismResolver
+}
+
+// subscribe registers observer created for requested sim until the context is
+// done, then calls close. Observer is called by RunStep while holding sim
+// lock.
+func (sub *Subscription) subscribe(
+	ctx context.Context,
+	id *graphql.ID,
+	createObserver func(s *sim.Sim) sim.Observer,
+	close func(),
+) error {
+	simulation, err := sub.registry.Get(getSimulationID(id))
+	if err != nil {
+		return err
+	}
+	// Observer is added even if context is cancelled meanwhile, so it has to
+	// be removed by the goroutine below
+	var remove func()
+	simulation.Sim.Exec(context.Background(), func(s *sim.Sim) error {
+		remove = s.Observe(createObserver(s))
+		return nil
+	})
+
+	go func() {
+		<-ctx.Done()
+		simulation.Sim.Exec(context.Background(), func(*sim.Sim) error {
+			remove()
+			return nil
+		})
+		close()
+	}()
+
+	return nil
+}
+
+func (sub *Subscription) IterationCompleted(
+	ctx context.Context,
+	args SimulationArgs,
+) (<-chan IterationUpdateResolver, error) {
+	c := make(chan IterationUpdateResolver, subscriptionBufferSize)
+
+	createObserver := func(*sim.Sim) sim.Observer {
+		return sim.StepHandler(func(s *sim.Sim, data sim.IterationData) {
+			update := IterationUpdateResolver{
+				AliveCellCount: int32(data.AliveCellCount),
+				CellCount:      int32(data.CellCount),
+				Number:         int32(data.Iteration),
+				SpeciesCount:   int32(len(data.Procreation.Species)),
+				waste:          data.Waste,
+			}
+
+			select {
+			case c <- update:
+			default:
+			}
+		})
+	}
+
+	return c, sub.subscribe(ctx, args.Simulation, createObserver, func() {
+		close(c)
+	})
+}
+
+func (sub *Subscription) getSpeciesUpdates(
+	ctx context.Context,
+	args SimulationArgs,
+	eventType sim.EventType,
+) (<-chan SpeciesUpdateResolver, error) {
+	c := make(chan SpeciesUpdateResolver, subscriptionBufferSize)
+
+	createObserver := func(s *sim.Sim) sim.Observer {
+		return sim.EventHandler(func(event sim.Event) {
+			if event.Type != eventType {
+				return
+			}
+
+			for _, species := range s.GetSpecies() {
+				if species.GetID() != event.SpeciesID {
+					continue
+				}
+
+				update := SpeciesUpdateResolver{
+					Diet:      getDietNames(species),
+					EmergedAt: int32(species.GetEmergedAt()),
+					ID:        int32(species.GetID()),
+					Iteration: int32(event.Iteration),
+					Name:      species.GetName(),
+				}
+
+				select {
+				case c <- update:
+				default:
+				}
+				return
+			}
+		})
+	}
+
+	return c, sub.subscribe(ctx, args.Simulation, createObserver, func() {
+		close(c)
+	})
+}
+
+func (sub *Subscription) SpeciesEmerged(
+	ctx context.Context,
+	args SimulationArgs,
+) (<-chan SpeciesUpdateResolver, error) {
+	return sub.getSpeciesUpdates(ctx, args, sim.EventSpeciesEmerged)
+}
+
+func (sub *Subscription) SpeciesExtinct(
+	ctx context.Context,
+	args SimulationArgs,
+) (<-chan SpeciesUpdateResolver, error) {
+	return sub.getSpeciesUpdates(ctx, args, sim.EventSpeciesExtinct)
+}
+
+type ViewportUpdatesArgs struct {
+	Area       AreaInput
+	Simulation *graphql.ID
+}
+
+// ViewportUpdates filters organisms from published snapshots in its own
+// goroutine, so that subscribers don't slow down steps while sim is locked.
+func (sub *Subscription) ViewportUpdates(
+	ctx context.Context,
+	args ViewportUpdatesArgs,
+) (<-chan ViewportUpdateResolver, error) {
+	c := make(chan ViewportUpdateResolver, subscriptionBufferSize)
+	snapshots := make(chan *sim.Snapshot, subscriptionBufferSize)
+
+	createObserver := func(*sim.Sim) sim.Observer {
+		return sim.StepHandler(func(s *sim.Sim, data sim.IterationData) {
+			select {
+			case snapshots <- s.GetSnapshot():
+			default:
+			}
+		})
+	}
+
+	// Observer is removed before snapshots are closed, so it never sends to
+	// closed channel
+	err := sub.subscribe(ctx, args.Simulation, createObserver, func() {
+		close(snapshots)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(c)
+		for snapshot := range snapshots {
+			organisms := snapshot.GetOrganisms().GetArea(args.Area.Start, args.Area.End)
+			update := ViewportUpdateResolver{
+				Iteration: int32(snapshot.GetIteration()),
+				Organisms: make([]ViewportOrganismResolver, len(organisms)),
+			}
+
+			for organismIndex, organism := range organisms {
+				species := organism.GetSpecies()
+				update.Organisms[organismIndex] = ViewportOrganismResolver{
+					Alive:    organism.IsAlive(),
+					Cells:    int32(len(organism.GetCells())),
+					Diet:     getDietNames(species),
+					ID:       int32(organism.GetID()),
+					Position: organism.GetPosition(),
+					Species:  int32(species.GetID()),
+				}
+			}
+
+			select {
+			case c <- update:
+			default:
+			}
+		}
+	}()
+
+	return c, nil
+}
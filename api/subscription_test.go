@@ -0,0 +1,246 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+	"github.com/gorilla/websocket"
+)
+
+func dialAPI(
+	t *testing.T,
+	server *httptest.Server,
+	protocol string,
+) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{protocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if err := conn.WriteJSON(wsMessage{Type: "connection_init"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn, messageType string) wsMessage {
+	for {
+		var message wsMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatal(err)
+		}
+		if message.Type == messageType {
+			return message
+		}
+		if message.Type == "error" {
+			t.Fatalf("Expected %s, got error %s", messageType, message.Payload)
+		}
+	}
+}
+
+func sendOperation(
+	t *testing.T,
+	conn *websocket.Conn,
+	messageType string,
+	id string,
+	query string,
+) {
+	payload, _ := json.Marshal(wsOperation{Query: query})
+	err := conn.WriteJSON(wsMessage{ID: id, Type: messageType, Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getTestRegistry(t *testing.T) (*registry.Registry, *sim.Sim) {
+	config := sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	}
+	r := registry.New(config)
+	simulation, err := r.Create(registry.DefaultID, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r, simulation.Sim
+}
+
+func runStep(s *sim.Sim) {
+	s.Lock()
+	s.RunStep(context.TODO())
+	s.Unlock()
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Run("sends iteration updates over graphql-transport-ws", func(t *testing.T) {
+		// Given
+		r, s := getTestRegistry(t)
+		server := httptest.NewServer(InitAPI(r, timelapse.NewStore(), nil, DefaultLimits))
+		defer server.Close()
+		conn := dialAPI(t, server, protocolGraphQLTransportWS)
+		defer conn.Close()
+		readMessage(t, conn, "connection_ack")
+
+		// When
+		sendOperation(t, conn, "subscribe", "1", `subscription {
+			iterationCompleted {
+				number
+				waste {
+					toxicity
+				}
+			}
+		}`)
+		// Subscription is registered asynchronously
+		time.Sleep(50 * time.Millisecond)
+		runStep(s)
+
+		// Then
+		message := readMessage(t, conn, "next")
+		var response struct {
+			Data struct {
+				IterationCompleted struct {
+					Number int
+				}
+			}
+		}
+		json.Unmarshal(message.Payload, &response)
+		if response.Data.IterationCompleted.Number != 1 {
+			t.Errorf("Expected %d, got %d", 1, response.Data.IterationCompleted.Number)
+		}
+	})
+
+	t.Run("releases sim after query over graphql-ws", func(t *testing.T) {
+		// Given
+		r, s := getTestRegistry(t)
+		server := httptest.NewServer(InitAPI(r, timelapse.NewStore(), nil, DefaultLimits))
+		defer server.Close()
+		conn := dialAPI(t, server, protocolGraphQLWS)
+		defer conn.Close()
+		readMessage(t, conn, "connection_ack")
+
+		// When
+		sendOperation(t, conn, "start", "1", `{ iteration { number } }`)
+		readMessage(t, conn, "data")
+		readMessage(t, conn, "complete")
+
+		// Then
+		done := make(chan bool)
+		go func() {
+			runStep(s)
+			done <- true
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("Sim is still locked by query")
+		}
+	})
+
+	t.Run("rejects duplicate operation ID", func(t *testing.T) {
+		// Given
+		r, _ := getTestRegistry(t)
+		server := httptest.NewServer(InitAPI(r, timelapse.NewStore(), nil, DefaultLimits))
+		defer server.Close()
+		conn := dialAPI(t, server, protocolGraphQLTransportWS)
+		defer conn.Close()
+		readMessage(t, conn, "connection_ack")
+		query := `subscription { speciesExtinct { id } }`
+
+		// When
+		sendOperation(t, conn, "subscribe", "1", query)
+		sendOperation(t, conn, "subscribe", "1", query)
+
+		// Then
+		var message wsMessage
+		err := conn.ReadJSON(&message)
+		if !websocket.IsCloseError(err, wsCloseDuplicateID) {
+			t.Errorf("Expected close %d, got %v", wsCloseDuplicateID, err)
+		}
+	})
+
+	t.Run("sends viewport updates from snapshot", func(t *testing.T) {
+		// Given
+		r, s := getTestRegistry(t)
+		server := httptest.NewServer(InitAPI(r, timelapse.NewStore(), nil, DefaultLimits))
+		defer server.Close()
+		conn := dialAPI(t, server, protocolGraphQLTransportWS)
+		defer conn.Close()
+		readMessage(t, conn, "connection_ack")
+
+		// When
+		sendOperation(t, conn, "subscribe", "1", `subscription {
+			viewportUpdates(area: {
+				start: { x: 0, y: 0 }
+				end: { x: 10000, y: 10000 }
+			}) {
+				iteration
+				organisms {
+					id
+				}
+			}
+		}`)
+		// Subscription is registered asynchronously
+		time.Sleep(50 * time.Millisecond)
+		runStep(s)
+
+		// Then
+		message := readMessage(t, conn, "next")
+		var response struct {
+			Data struct {
+				ViewportUpdates struct {
+					Iteration int
+					Organisms []struct{ ID int }
+				}
+			}
+		}
+		json.Unmarshal(message.Payload, &response)
+		if response.Data.ViewportUpdates.Iteration != 1 {
+			t.Errorf("Expected %d, got %d", 1, response.Data.ViewportUpdates.Iteration)
+		}
+		expected := len(s.GetSnapshot().GetOrganisms())
+		if len(response.Data.ViewportUpdates.Organisms) != expected {
+			t.Errorf(
+				"Expected %d, got %d",
+				expected,
+				len(response.Data.ViewportUpdates.Organisms),
+			)
+		}
+	})
+
+	t.Run("rejects repeated connection_init", func(t *testing.T) {
+		// Given
+		r, _ := getTestRegistry(t)
+		server := httptest.NewServer(InitAPI(r, timelapse.NewStore(), nil, DefaultLimits))
+		defer server.Close()
+		conn := dialAPI(t, server, protocolGraphQLTransportWS)
+		defer conn.Close()
+		readMessage(t, conn, "connection_ack")
+
+		// When
+		if err := conn.WriteJSON(wsMessage{Type: "connection_init"}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Then
+		var message wsMessage
+		err := conn.ReadJSON(&message)
+		if !websocket.IsCloseError(err, wsCloseTooManyInits) {
+			t.Errorf("Expected close %d, got %v", wsCloseTooManyInits, err)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/dominik-zeglen/aquarium/render"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Recordings started through API must fit in memory
+const maxTimelapseFrames = 1000
+
+type RenderInput struct {
+	Area   *AreaInput
+	Width  *int32
+	Height *int32
+	Scheme *string
+	Cells  *bool
+}
+
+func (input *RenderInput) options() render.Options {
+	options := render.Options{}
+	if input == nil {
+		return options
+	}
+
+	if input.Area != nil {
+		options.Start = input.Area.Start
+		options.End = input.Area.End
+	}
+	if input.Width != nil {
+		options.Width = int(*input.Width)
+	}
+	if input.Height != nil {
+		options.Height = int(*input.Height)
+	}
+	if input.Scheme != nil {
+		options.Scheme = render.Scheme(strings.ToLower(*input.Scheme))
+	}
+	if input.Cells != nil {
+		options.Cells = *input.Cells
+	}
+
+	return options
+}
+
+type TimelapseInput struct {
+	Start  *int32
+	End    int32
+	Every  *int32
+	Window *int32
+	Delay  *int32
+	Render *RenderInput
+}
+
+func (input TimelapseInput) config(iteration int) (timelapse.Config, error) {
+	config := timelapse.Config{
+		Start:  iteration,
+		End:    int(input.End),
+		Every:  timelapse.DefaultEvery,
+		Render: input.Render.options(),
+	}
+	if input.Start != nil {
+		config.Start = int(*input.Start)
+	}
+	if input.Every != nil {
+		config.Every = int(*input.Every)
+	}
+	if input.Window != nil {
+		config.Window = int(*input.Window)
+	}
+	if input.Delay != nil {
+		config.Delay = int(*input.Delay)
+	}
+
+	if config.Start < iteration {
+		return config, fmt.Errorf(
+			"Timelapse must not start in the past, current iteration is %d",
+			iteration,
+		)
+	}
+	if config.Every < 1 {
+		return config, fmt.Errorf("Every must be positive, got %d", config.Every)
+	}
+
+	frames := config.Window
+	if frames == 0 || frames > maxTimelapseFrames {
+		frames = (config.End-config.Start)/config.Every + 1
+	}
+	if frames > maxTimelapseFrames {
+		return config, fmt.Errorf(
+			"Timelapse would keep %d frames, limit is %d, set window or capture frames less often",
+			frames,
+			maxTimelapseFrames,
+		)
+	}
+
+	return config, nil
+}
+
+type TimelapseResolver struct {
+	recorder *timelapse.Recorder
+}
+
+func createTimelapseResolverList(recorders []*timelapse.Recorder) []TimelapseResolver {
+	resolvers := make([]TimelapseResolver, len(recorders))
+
+	for recorderIndex := range recorders {
+		resolvers[recorderIndex] = TimelapseResolver{recorders[recorderIndex]}
+	}
+
+	return resolvers
+}
+
+func (res TimelapseResolver) ID() graphql.ID {
+	return graphql.ID(res.recorder.ID)
+}
+func (res TimelapseResolver) Simulation() graphql.ID {
+	return graphql.ID(res.recorder.SimulationID)
+}
+func (res TimelapseResolver) Status() string {
+	return strings.ToUpper(string(res.recorder.GetStatus()))
+}
+func (res TimelapseResolver) Frames() int32 {
+	return int32(res.recorder.GetFrameCount())
+}
+func (res TimelapseResolver) Iteration() int32 {
+	return int32(res.recorder.GetIteration())
+}
+func (res TimelapseResolver) Start() int32 {
+	return int32(res.recorder.GetConfig().Start)
+}
+func (res TimelapseResolver) End() int32 {
+	return int32(res.recorder.GetConfig().End)
+}
+func (res TimelapseResolver) Every() int32 {
+	return int32(res.recorder.GetConfig().Every)
+}
+func (res TimelapseResolver) Error() *string {
+	err := res.recorder.GetError()
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	return &message
+}
+
+type TimelapseArgs struct {
+	ID graphql.ID
+}
+
+func (q *Query) Timelapse(args TimelapseArgs) *TimelapseResolver {
+	recorder, err := q.timelapses.Get(string(args.ID))
+	if err != nil {
+		return nil
+	}
+
+	return &TimelapseResolver{recorder}
+}
+
+func (q *Query) Timelapses() []TimelapseResolver {
+	return createTimelapseResolverList(q.timelapses.List())
+}
+
+type RecordTimelapseArgs struct {
+	Input      TimelapseInput
+	Simulation *graphql.ID
+}
+
+func (m *Mutation) RecordTimelapse(
+	ctx context.Context,
+	args RecordTimelapseArgs,
+) (*TimelapseResolver, error) {
+	if err := middleware.Authorize(ctx, middleware.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	id := getSimulationID(args.Simulation)
+	simulation, err := m.registry.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := args.Input.config(simulation.Sim.GetSnapshot().GetIteration())
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := m.timelapses.Record(id, simulation.Sim, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimelapseResolver{recorder}, nil
+}
@@ -0,0 +1,328 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/gorilla/websocket"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// Legacy protocol of subscriptions-transport-ws, used by Apollo
+	protocolGraphQLWS = "graphql-ws"
+	// Protocol of graphql-ws library
+	protocolGraphQLTransportWS = "graphql-transport-ws"
+)
+
+const wsKeepAliveInterval = 10 * time.Second
+
+// Close codes defined by graphql-transport-ws
+const (
+	wsCloseBadRequest     = 4400
+	wsCloseDuplicateID    = 4409
+	wsCloseNotInitialised = 4401
+	wsCloseTooManyInits   = 4429
+)
+
+type wsMessageTypes struct {
+	ack      string
+	complete string
+	data     string
+	error    string
+	ping     string
+	start    string
+	stop     string
+}
+
+var wsMessageTypesByProtocol = map[string]wsMessageTypes{
+	protocolGraphQLWS: {
+		ack:      "connection_ack",
+		complete: "complete",
+		data:     "data",
+		error:    "error",
+		ping:     "ka",
+		start:    "start",
+		stop:     "stop",
+	},
+	protocolGraphQLTransportWS: {
+		ack:      "connection_ack",
+		complete: "complete",
+		data:     "next",
+		error:    "error",
+		ping:     "ping",
+		start:    "subscribe",
+		stop:     "complete",
+	},
+}
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsOperation struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// WebSocketHandler serves queries and subscriptions over both graphql-ws and
+// graphql-transport-ws protocols
+type WebSocketHandler struct {
+	analyzer *costAnalyzer
+	limiter  *rateLimiter
+	schema   *graphql.Schema
+	upgrader websocket.Upgrader
+}
+
+func NewWebSocketHandler(
+	schema *graphql.Schema,
+	checkOrigin func(r *http.Request) bool,
+	analyzer *costAnalyzer,
+	limiter *rateLimiter,
+) *WebSocketHandler {
+	return &WebSocketHandler{
+		analyzer: analyzer,
+		limiter:  limiter,
+		schema:   schema,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin,
+			Subprotocols: []string{
+				protocolGraphQLTransportWS,
+				protocolGraphQLWS,
+			},
+		},
+	}
+}
+
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	protocol := conn.Subprotocol()
+	if protocol == "" {
+		protocol = protocolGraphQLWS
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	c := wsConnection{
+		analyzer:   h.analyzer,
+		client:     middleware.GetClientID(r),
+		conn:       conn,
+		limiter:    h.limiter,
+		schema:     h.schema,
+		types:      wsMessageTypesByProtocol[protocol],
+		legacy:     protocol == protocolGraphQLWS,
+		operations: map[string]context.CancelFunc{},
+	}
+	c.serve(ctx)
+}
+
+type wsConnection struct {
+	analyzer    *costAnalyzer
+	client      string
+	conn        *websocket.Conn
+	limiter     *rateLimiter
+	schema      *graphql.Schema
+	types       wsMessageTypes
+	legacy      bool
+	initialised bool
+	// Guards writes and operations, websocket.Conn allows one writer only
+	lock       sync.Mutex
+	operations map[string]context.CancelFunc
+}
+
+func (c *wsConnection) send(message wsMessage) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.conn.WriteJSON(message)
+}
+
+func (c *wsConnection) close(code int, reason string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(time.Second),
+	)
+}
+
+func (c *wsConnection) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(wsKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.send(wsMessage{Type: c.types.ping}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConnection) serve(ctx context.Context) {
+	for {
+		var message wsMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		switch message.Type {
+		case "connection_init":
+			if c.initialised && !c.legacy {
+				c.close(wsCloseTooManyInits, "Too many initialisation requests")
+				return
+			}
+			c.initialised = true
+			c.send(wsMessage{Type: c.types.ack})
+			if c.legacy {
+				c.send(wsMessage{Type: c.types.ping})
+				go c.keepAlive(ctx)
+			}
+
+		case c.types.start:
+			if !c.initialised && !c.legacy {
+				c.close(wsCloseNotInitialised, "Unauthorized")
+				return
+			}
+			if !c.start(ctx, message) {
+				return
+			}
+
+		case c.types.stop:
+			c.stop(message.ID)
+
+		case "ping":
+			c.send(wsMessage{Type: "pong", Payload: message.Payload})
+
+		case "pong":
+
+		case "connection_terminate":
+			return
+
+		default:
+			if !c.legacy {
+				c.close(wsCloseBadRequest, "Unknown message type "+message.Type)
+				return
+			}
+			c.sendError(message.ID, "Unknown message type "+message.Type)
+		}
+	}
+}
+
+func (c *wsConnection) sendError(id string, message string) {
+	payload, _ := json.Marshal(map[string]string{"message": message})
+	if !c.legacy {
+		payload, _ = json.Marshal([]map[string]string{{"message": message}})
+	}
+
+	c.send(wsMessage{ID: id, Type: c.types.error, Payload: payload})
+}
+
+// start returns false if connection has to be closed
+func (c *wsConnection) start(ctx context.Context, message wsMessage) bool {
+	var operation wsOperation
+	if err := json.Unmarshal(message.Payload, &operation); err != nil {
+		if !c.legacy {
+			c.close(wsCloseBadRequest, "Invalid operation payload")
+			return false
+		}
+		c.sendError(message.ID, "Invalid operation payload")
+		return true
+	}
+
+	// Operations over limits are rejected without closing connection, since
+	// the client could not know about them upfront
+	if ok, _ := c.limiter.allow(c.client); !ok {
+		c.sendError(message.ID, errRateLimit.Error())
+		return true
+	}
+	operationType, err := c.analyzer.check(
+		operation.Query,
+		operation.OperationName,
+		operation.Variables,
+	)
+	if err != nil {
+		c.sendError(message.ID, err.Error())
+		return true
+	}
+
+	opCtx, cancel := c.analyzer.limits.withTimeout(ctx, operationType)
+
+	c.lock.Lock()
+	_, exists := c.operations[message.ID]
+	if !exists {
+		c.operations[message.ID] = cancel
+	}
+	c.lock.Unlock()
+
+	if exists {
+		cancel()
+		if !c.legacy {
+			c.close(wsCloseDuplicateID, "Subscriber for "+message.ID+" already exists")
+			return false
+		}
+		c.sendError(message.ID, "Operation "+message.ID+" already exists")
+		return true
+	}
+
+	responses, err := c.schema.Subscribe(
+		opCtx,
+		operation.Query,
+		operation.OperationName,
+		operation.Variables,
+	)
+	if err != nil {
+		cancel()
+		c.stop(message.ID)
+		c.sendError(message.ID, err.Error())
+		return true
+	}
+
+	go func() {
+		defer cancel()
+
+		for response := range responses {
+			payload, err := json.Marshal(response)
+			if err != nil {
+				continue
+			}
+			c.send(wsMessage{ID: message.ID, Type: c.types.data, Payload: payload})
+		}
+
+		// Operations stopped by client must not be completed
+		if opCtx.Err() != context.Canceled {
+			c.send(wsMessage{ID: message.ID, Type: c.types.complete})
+		}
+		c.stop(message.ID)
+	}()
+
+	return true
+}
+
+func (c *wsConnection) stop(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cancel, ok := c.operations[id]; ok {
+		cancel()
+		delete(c.operations, id)
+	}
+}
@@ -0,0 +1,166 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/analytics"
+	"github.com/dominik-zeglen/aquarium/eventlog"
+	"github.com/dominik-zeglen/aquarium/export"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/timelapse"
+)
+
+type Outcome string
+
+const (
+	OutcomeSurvived = Outcome("survived")
+	OutcomeExtinct  = Outcome("extinct")
+)
+
+type Config struct {
+	Sim sim.SimConfig
+	// Stop after this number of iterations, 0 runs until extinction
+	Iterations int
+	// Summary, snapshots and metrics are written there if not empty
+	OutputDir string
+	// Write snapshot every this number of iterations, 0 disables snapshots
+	SnapshotEvery int
+	// Metrics are exported only if path is set
+	Export export.Config
+	// Events are appended to this file if not empty
+	EventLog string
+	// Logged events are flushed at this interval, or after each one if 0
+	EventLogFlush time.Duration
+	// Timelapse is recorded only if path is set
+	Timelapse timelapse.Config
+}
+
+type Snapshot struct {
+	Data      sim.IterationData `json:"data"`
+	Analytics analytics.Report  `json:"analytics"`
+}
+
+type Summary struct {
+	Seed       int64            `json:"seed"`
+	Outcome    Outcome          `json:"outcome"`
+	Iterations int              `json:"iterations"`
+	Organisms  int              `json:"organisms"`
+	AliveCells int              `json:"aliveCells"`
+	Species    int              `json:"species"`
+	Toxicity   float64          `json:"toxicity"`
+	Duration   float64          `json:"duration"`
+	Analytics  analytics.Report `json:"analytics"`
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func Run(ctx context.Context, config Config) (Summary, error) {
+	start := time.Now()
+	summary := Summary{Seed: config.Sim.Seed}
+
+	if config.OutputDir != "" {
+		if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+			return summary, err
+		}
+	}
+
+	var exporter *export.Exporter
+	if config.Export.Path != "" {
+		e, err := export.New(config.Export)
+		if err != nil {
+			return summary, err
+		}
+		defer e.Close()
+		exporter = e
+	}
+
+	s := sim.Sim{}
+	s.Create(config.Sim)
+
+	if config.EventLog != "" {
+		w, err := eventlog.New(config.EventLog, config.EventLogFlush)
+		if err != nil {
+			return summary, err
+		}
+		defer w.Close()
+		s.OnEvent(w.OnEvent)
+	}
+
+	if config.Timelapse.Path != "" {
+		recorder, err := timelapse.New(config.Timelapse)
+		if err != nil {
+			return summary, err
+		}
+		defer recorder.Close()
+		s.Observe(recorder)
+	}
+
+	data := sim.IterationData{}
+
+	for config.Iterations == 0 || s.GetIteration() < config.Iterations {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		data = s.RunStep(ctx)
+
+		if exporter != nil {
+			if err := exporter.Write(&s, data); err != nil {
+				return summary, err
+			}
+		}
+
+		if config.OutputDir != "" &&
+			config.SnapshotEvery > 0 &&
+			data.Iteration%config.SnapshotEvery == 0 {
+			err := writeJSON(
+				filepath.Join(
+					config.OutputDir,
+					fmt.Sprintf("snapshot-%08d.json", data.Iteration),
+				),
+				Snapshot{data, analytics.Compute(&s)},
+			)
+			if err != nil {
+				return summary, err
+			}
+		}
+
+		if s.GetCellCount() == 0 {
+			break
+		}
+	}
+
+	summary.Outcome = OutcomeSurvived
+	if s.GetCellCount() == 0 {
+		summary.Outcome = OutcomeExtinct
+	}
+	summary.Iterations = s.GetIteration()
+	summary.Organisms = s.GetCellCount()
+	summary.AliveCells = data.AliveCellCount
+	summary.Species = len(s.GetSpecies())
+	summary.Toxicity = s.GetEnvironment().GetToxicity()
+	summary.Duration = time.Since(start).Seconds()
+	summary.Analytics = analytics.Compute(&s)
+
+	if config.OutputDir != "" {
+		err := writeJSON(filepath.Join(config.OutputDir, "summary.json"), summary)
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
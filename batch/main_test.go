@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func getConfig() Config {
+	return Config{
+		Sim: sim.SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			Seed:               42,
+			StartCells:         10,
+		},
+		Iterations: 50,
+	}
+}
+
+func TestRun(t *testing.T) {
+	// Given
+	dir, err := ioutil.TempDir("", "batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := getConfig()
+	config.OutputDir = dir
+	config.SnapshotEvery = 25
+
+	// When
+	summary, err := Run(context.TODO(), config)
+
+	// Then
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Outcome == OutcomeSurvived && summary.Iterations != 50 {
+		t.Errorf("Expected 50, got %d", summary.Iterations)
+	}
+
+	for _, name := range []string{
+		"summary.json",
+		"snapshot-00000025.json",
+		"snapshot-00000050.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be written: %s", name, err)
+		}
+	}
+}
+
+func TestRunIsReproducible(t *testing.T) {
+	// When
+	first, err := Run(context.TODO(), getConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Run(context.TODO(), getConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Then
+	if first.Organisms != second.Organisms || first.Toxicity != second.Toxicity {
+		t.Errorf(
+			"Expected same outcome, got %d vs %d organisms",
+			first.Organisms,
+			second.Organisms,
+		)
+	}
+}
@@ -0,0 +1,302 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type Config struct {
+	Dir string
+	// Save every this number of iterations, 0 disables it
+	Every int
+	// Save when this much time passed since the last checkpoint, 0 disables it
+	Interval time.Duration
+	// Remove older checkpoints, so that only this number of them is left, 0
+	// keeps all of them
+	Keep int
+}
+
+const (
+	prefix = "checkpoint-"
+	suffix = ".gob"
+)
+
+func getName(iteration int) string {
+	return fmt.Sprintf("%s%08d%s", prefix, iteration, suffix)
+}
+
+// List returns paths of checkpoints in dir, from the newest to the oldest
+func List(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := map[string]int{}
+	for _, file := range files {
+		var iteration int
+		_, err := fmt.Sscanf(file.Name(), prefix+"%d"+suffix, &iteration)
+		if err != nil || file.IsDir() || file.Name() != getName(iteration) {
+			continue
+		}
+		iterations[filepath.Join(dir, file.Name())] = iteration
+	}
+
+	paths := make([]string, 0, len(iterations))
+	for path := range iterations {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return iterations[paths[i]] > iterations[paths[j]]
+	})
+
+	return paths, nil
+}
+
+// Write saves state of s to dir in file named after current iteration and
+// returns its path. It must be called while holding sim lock or when sim is
+// not running.
+func Write(dir string, s *sim.Sim) (string, error) {
+	buf := bytes.Buffer{}
+	if err := s.WriteCheckpoint(&buf); err != nil {
+		return "", err
+	}
+
+	return writeFile(dir, s.GetIteration(), buf.Bytes())
+}
+
+// writeFile renames file once it's complete and synced, so it's never left
+// half written
+func writeFile(dir string, iteration int, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	f, err := ioutil.TempFile(dir, ".checkpoint-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, getName(iteration))
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	if err := syncDir(dir); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// syncDir makes rename durable, otherwise crash could leave directory without
+// the new checkpoint even though its file was synced
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Prune removes all but keep newest checkpoints in dir
+func Prune(dir string, keep int) error {
+	paths, err := List(dir)
+	if err != nil {
+		return err
+	}
+
+	for pathIndex := keep; pathIndex < len(paths); pathIndex++ {
+		if err := os.Remove(paths[pathIndex]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore passes the newest checkpoint in dir which can be read to load and
+// returns its path. Invalid checkpoints are skipped, while empty path is
+// returned if there's none left.
+func Restore(dir string, load func(r io.Reader) error) (string, error) {
+	paths, err := List(dir)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Printf("Skipping checkpoint %s: %s", path, err)
+			continue
+		}
+		err = load(f)
+		f.Close()
+		if err != nil {
+			log.Printf("Skipping checkpoint %s: %s", path, err)
+			continue
+		}
+
+		return path, nil
+	}
+
+	return "", nil
+}
+
+// Checkpoints are encoded while sim is locked, but written in the background.
+// Sim step waits once queue is full, so slow disk can't pile them up.
+const writeQueueSize = 1
+
+type encodedCheckpoint struct {
+	iteration int
+	data      []byte
+}
+
+// Checkpointer saves sim it observes periodically
+type Checkpointer struct {
+	config  Config
+	done    chan struct{}
+	savedAt time.Time
+	// Guards checkpoint files, so that pruning doesn't race with writes
+	lock        sync.Mutex
+	queue       chan encodedCheckpoint
+	queueClosed bool
+	queueLock   sync.Mutex
+}
+
+func New(config Config) (*Checkpointer, error) {
+	if config.Dir == "" {
+		return nil, fmt.Errorf("Checkpoint directory not set")
+	}
+	if config.Every < 0 || config.Interval < 0 || config.Keep < 0 {
+		return nil, fmt.Errorf("Checkpoint settings must not be negative")
+	}
+
+	c := &Checkpointer{
+		config:  config,
+		done:    make(chan struct{}),
+		savedAt: time.Now(),
+		queue:   make(chan encodedCheckpoint, writeQueueSize),
+	}
+	go c.writeQueued()
+
+	return c, nil
+}
+
+func (c *Checkpointer) writeQueued() {
+	defer close(c.done)
+
+	for checkpoint := range c.queue {
+		_, err := c.save(checkpoint.iteration, checkpoint.data)
+		if err != nil {
+			log.Printf(
+				"Could not save checkpoint at iteration %d: %s",
+				checkpoint.iteration,
+				err,
+			)
+		}
+	}
+}
+
+// save writes encoded checkpoint and removes ones exceeding the limit
+func (c *Checkpointer) save(iteration int, data []byte) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	path, err := writeFile(c.config.Dir, iteration, data)
+	if err != nil {
+		return "", err
+	}
+
+	if c.config.Keep > 0 {
+		if err := Prune(c.config.Dir, c.config.Keep); err != nil {
+			return path, err
+		}
+	}
+
+	return path, nil
+}
+
+// Save writes checkpoint of s right away and removes ones exceeding the limit.
+// It must be called while holding sim lock or when sim is not running.
+func (c *Checkpointer) Save(s *sim.Sim) (string, error) {
+	buf := bytes.Buffer{}
+	if err := s.WriteCheckpoint(&buf); err != nil {
+		return "", err
+	}
+	c.savedAt = time.Now()
+
+	return c.save(s.GetIteration(), buf.Bytes())
+}
+
+func (c *Checkpointer) isDue(iteration int) bool {
+	if c.config.Every > 0 && iteration%c.config.Every == 0 {
+		return true
+	}
+
+	return c.config.Interval > 0 && time.Since(c.savedAt) >= c.config.Interval
+}
+
+func (c *Checkpointer) OnStep(s *sim.Sim, data sim.IterationData) {
+	if !c.isDue(data.Iteration) {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	if err := s.WriteCheckpoint(&buf); err != nil {
+		log.Printf("Could not save checkpoint at iteration %d: %s", data.Iteration, err)
+		return
+	}
+	c.savedAt = time.Now()
+
+	c.queueLock.Lock()
+	if !c.queueClosed {
+		c.queue <- encodedCheckpoint{data.Iteration, buf.Bytes()}
+	}
+	c.queueLock.Unlock()
+}
+
+func (c *Checkpointer) OnEvent(event sim.Event) {}
+
+// Close stops saving checkpoints in the background and waits until queued
+// ones are written. Save can still be called afterwards.
+func (c *Checkpointer) Close() {
+	c.queueLock.Lock()
+	if !c.queueClosed {
+		c.queueClosed = true
+		close(c.queue)
+	}
+	c.queueLock.Unlock()
+
+	<-c.done
+}
@@ -0,0 +1,156 @@
+package checkpoint
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func getTestSim() *sim.Sim {
+	s := &sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+
+	return s
+}
+
+func getTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestWrite(t *testing.T) {
+	// Given
+	dir := getTestDir(t)
+	defer os.RemoveAll(dir)
+
+	s := getTestSim()
+	for it := 0; it < 5; it++ {
+		s.RunStep(context.TODO())
+	}
+
+	// When
+	path, err := Write(filepath.Join(dir, "nested"), s)
+
+	// Then
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "checkpoint-00000005.gob" {
+		t.Errorf("Expected %s, got %s", "checkpoint-00000005.gob", filepath.Base(path))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	restored := &sim.Sim{}
+	if err := restored.ReadCheckpoint(f); err != nil {
+		t.Fatal(err)
+	}
+	if restored.GetIteration() != 5 {
+		t.Errorf("Expected %d, got %d", 5, restored.GetIteration())
+	}
+
+	files, _ := ioutil.ReadDir(filepath.Join(dir, "nested"))
+	if len(files) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(files))
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("skips invalid checkpoints", func(t *testing.T) {
+		// Given
+		dir := getTestDir(t)
+		defer os.RemoveAll(dir)
+
+		s := getTestSim()
+		s.RunStep(context.TODO())
+		Write(dir, s)
+		s.RunStep(context.TODO())
+		Write(dir, s)
+		ioutil.WriteFile(filepath.Join(dir, getName(100)), []byte("corrupted"), 0644)
+
+		// When
+		restored := &sim.Sim{}
+		path, err := Restore(dir, restored.ReadCheckpoint)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Base(path) != getName(2) {
+			t.Errorf("Expected %s, got %s", getName(2), filepath.Base(path))
+		}
+		if restored.GetIteration() != 2 {
+			t.Errorf("Expected %d, got %d", 2, restored.GetIteration())
+		}
+	})
+
+	t.Run("returns empty path if directory does not exist", func(t *testing.T) {
+		// Given
+		dir := getTestDir(t)
+		defer os.RemoveAll(dir)
+
+		// When
+		restored := &sim.Sim{}
+		path, err := Restore(filepath.Join(dir, "missing"), restored.ReadCheckpoint)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != "" {
+			t.Errorf("Expected empty path, got %s", path)
+		}
+	})
+}
+
+func TestCheckpointer(t *testing.T) {
+	// Given
+	dir := getTestDir(t)
+	defer os.RemoveAll(dir)
+
+	checkpointer, err := New(Config{Dir: dir, Every: 5, Keep: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := getTestSim()
+	s.Observe(checkpointer)
+
+	// When
+	for it := 0; it < 23; it++ {
+		s.RunStep(context.TODO())
+	}
+	checkpointer.Close()
+
+	// Then
+	paths, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{getName(20), getName(15)}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, paths)
+	}
+	for pathIndex := range expected {
+		if filepath.Base(paths[pathIndex]) != expected[pathIndex] {
+			t.Errorf("Expected %s, got %s", expected[pathIndex], filepath.Base(paths[pathIndex]))
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/api"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// Duration is read from strings like "10s" or "1m30s"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("Duration must be string like \"10s\", got %s", data)
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("Duration must be string like \"10s\", got %s", data)
+	}
+	*d = Duration(duration)
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+type Server struct {
+	Port           int      `json:"port"`
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// API is open to anyone if empty
+	Keys string `json:"keys"`
+}
+
+// API limits, zero values disable them
+type API struct {
+	MaxDepth  int      `json:"maxDepth"`
+	MaxCost   int      `json:"maxCost"`
+	Rate      float64  `json:"rate"`
+	RateBurst int      `json:"rateBurst"`
+	Timeout   Duration `json:"timeout"`
+}
+
+type Tracing struct {
+	Enabled   bool   `json:"enabled"`
+	AgentHost string `json:"agentHost"`
+}
+
+type Batch struct {
+	// Stop after this number of iterations, 0 runs until extinction
+	Iterations int `json:"iterations"`
+	// Summary and snapshots are written there if not empty
+	OutputDir string `json:"outputDir"`
+	// Write snapshot every this number of iterations, 0 disables snapshots
+	SnapshotEvery int `json:"snapshotEvery"`
+}
+
+type Checkpoint struct {
+	// Default simulation is saved there on shutdown and resumed from the newest
+	// checkpoint on start if not empty
+	Dir string `json:"dir"`
+	// Save every this number of iterations, 0 disables it
+	Every int `json:"every"`
+	// Save at this interval, 0 disables it
+	Interval Duration `json:"interval"`
+	// Keep only this number of the newest checkpoints, 0 keeps all
+	Keep int `json:"keep"`
+}
+
+// Config holds settings read from file, environment and flags, in order of
+// increasing precedence
+type Config struct {
+	Sim        sim.SimConfig `json:"sim"`
+	Server     Server        `json:"server"`
+	API        API           `json:"api"`
+	Tracing    Tracing       `json:"tracing"`
+	Batch      Batch         `json:"batch"`
+	Checkpoint Checkpoint    `json:"checkpoint"`
+}
+
+func Default() Config {
+	return Config{
+		Sim: sim.SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			StartCells:         10,
+			WarmupIterations:   5e4,
+		},
+		API: API{
+			MaxDepth:  api.DefaultLimits.MaxDepth,
+			MaxCost:   api.DefaultLimits.MaxCost,
+			Rate:      api.DefaultLimits.Rate,
+			RateBurst: api.DefaultLimits.RateBurst,
+			Timeout:   Duration(api.DefaultLimits.Timeout),
+		},
+		Checkpoint: Checkpoint{
+			Keep: 3,
+		},
+	}
+}
+
+func (c Config) GetLimits() api.Limits {
+	return api.Limits{
+		MaxDepth:  c.API.MaxDepth,
+		MaxCost:   c.API.MaxCost,
+		Rate:      c.API.Rate,
+		RateBurst: c.API.RateBurst,
+		Timeout:   time.Duration(c.API.Timeout),
+	}
+}
+
+// getLine returns line number of byte at offset
+func getLine(r io.ReadSeeker, offset int64) int {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0
+	}
+	data := make([]byte, offset)
+	n, _ := io.ReadFull(r, data)
+
+	return strings.Count(string(data[:n]), "\n") + 1
+}
+
+// Load reads JSON settings from r on top of base, settings missing in r are
+// left untouched
+func Load(r io.ReadSeeker, base Config) (Config, error) {
+	c := base
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&c)
+	switch err := err.(type) {
+	case nil:
+		return c, nil
+	case *json.SyntaxError:
+		return base, fmt.Errorf(
+			"Could not parse config, line %d: %s",
+			getLine(r, err.Offset),
+			err,
+		)
+	case *json.UnmarshalTypeError:
+		return base, fmt.Errorf(
+			"Could not parse config, line %d: %s must be %s, got %s",
+			getLine(r, err.Offset),
+			err.Field,
+			err.Type,
+			err.Value,
+		)
+	}
+
+	return base, fmt.Errorf("Could not parse config: %s", err)
+}
+
+func LoadFile(path string, base Config) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return base, err
+	}
+	defer f.Close()
+
+	c, err := Load(f, base)
+	if err != nil {
+		return base, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return c, nil
+}
+
+// FromEnv overrides settings with PORT, ALLOWED_ORIGINS and JAEGER_AGENT_HOST
+// environment variables if they're set
+func FromEnv(c Config, getenv func(string) string) (Config, error) {
+	if port := getenv("PORT"); port != "" {
+		value, err := strconv.Atoi(port)
+		if err != nil {
+			return c, fmt.Errorf("PORT must be a number, got %s", port)
+		}
+		c.Server.Port = value
+	}
+
+	if origins := getenv("ALLOWED_ORIGINS"); origins != "" {
+		c.Server.AllowedOrigins = strings.Split(origins, ",")
+	}
+
+	if host := getenv("JAEGER_AGENT_HOST"); host != "" {
+		c.Tracing.AgentHost = host
+	}
+
+	return c, nil
+}
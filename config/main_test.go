@@ -0,0 +1,188 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("keeps settings missing in file", func(t *testing.T) {
+		// Given
+		r := strings.NewReader(`{"sim": {"maxOrganisms": 50}, "api": {"timeout": "3s"}}`)
+
+		// When
+		c, err := Load(r, Default())
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Sim.MaxOrganisms != 50 {
+			t.Errorf("Expected %d, got %d", 50, c.Sim.MaxOrganisms)
+		}
+		if c.Sim.StartCells != Default().Sim.StartCells {
+			t.Errorf("Expected %d, got %d", Default().Sim.StartCells, c.Sim.StartCells)
+		}
+		if time.Duration(c.API.Timeout) != 3*time.Second {
+			t.Errorf("Expected %s, got %s", 3*time.Second, time.Duration(c.API.Timeout))
+		}
+	})
+
+	t.Run("reports line of invalid value", func(t *testing.T) {
+		// Given
+		r := strings.NewReader("{\n  \"sim\": {\n    \"startCells\": \"ten\"\n  }\n}")
+
+		// When
+		_, err := Load(r, Default())
+
+		// Then
+		if err == nil {
+			t.Fatal("Expected error")
+		}
+		if !strings.Contains(err.Error(), "line 3") ||
+			!strings.Contains(err.Error(), "sim.startCells") {
+			t.Errorf("Expected line and field in error, got %s", err)
+		}
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		// Given
+		r := strings.NewReader(`{"server": {"prot": 8000}}`)
+
+		// When
+		_, err := Load(r, Default())
+
+		// Then
+		if err == nil || !strings.Contains(err.Error(), "prot") {
+			t.Errorf("Expected unknown field error, got %v", err)
+		}
+	})
+
+	t.Run("loads example file", func(t *testing.T) {
+		// When
+		c, err := LoadFile("../config.example.json", Default())
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.ValidateServer(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Run("overrides file settings", func(t *testing.T) {
+		// Given
+		c := Default()
+		c.Server.Port = 8000
+		env := map[string]string{
+			"PORT":            "9000",
+			"ALLOWED_ORIGINS": "http://a,http://b",
+		}
+
+		// When
+		c, err := FromEnv(c, func(key string) string { return env[key] })
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Server.Port != 9000 {
+			t.Errorf("Expected %d, got %d", 9000, c.Server.Port)
+		}
+		if len(c.Server.AllowedOrigins) != 2 {
+			t.Errorf("Expected %d, got %d", 2, len(c.Server.AllowedOrigins))
+		}
+	})
+
+	t.Run("rejects invalid port", func(t *testing.T) {
+		// When
+		_, err := FromEnv(Default(), func(key string) string { return "http" })
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+
+	t.Run("leaves settings if variables are not set", func(t *testing.T) {
+		// Given
+		c := Default()
+		c.Tracing.AgentHost = "jaeger"
+
+		// When
+		c, err := FromEnv(c, func(string) string { return "" })
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Tracing.AgentHost != "jaeger" {
+			t.Errorf("Expected %s, got %s", "jaeger", c.Tracing.AgentHost)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("lists every invalid setting", func(t *testing.T) {
+		// Given
+		c := Default()
+		c.Sim.MaxOrganisms = 0
+		mutationRate := 2.
+		c.Sim.MutationRate = &mutationRate
+		c.Tracing.Enabled = true
+
+		// When
+		err := c.Validate()
+
+		// Then
+		errs, ok := err.(ValidationError)
+		if !ok {
+			t.Fatalf("Expected validation error, got %v", err)
+		}
+		if len(errs) != 3 {
+			t.Errorf("Expected %d, got %d: %s", 3, len(errs), err)
+		}
+	})
+
+	t.Run("requires port and origins to serve API", func(t *testing.T) {
+		// Given
+		c := Default()
+
+		// When
+		err := c.ValidateServer()
+
+		// Then
+		if err == nil || !strings.Contains(err.Error(), "server.port") ||
+			!strings.Contains(err.Error(), "server.allowedOrigins") {
+			t.Errorf("Expected port and origins errors, got %v", err)
+		}
+	})
+
+	t.Run("rejects more divisions than environment size allows", func(t *testing.T) {
+		// Given
+		c := Default()
+		c.Sim.EnvWidth = 3
+
+		// When
+		err := c.Validate()
+
+		// Then
+		if err == nil || !strings.Contains(err.Error(), "sim.envDivisions") {
+			t.Errorf("Expected envDivisions error, got %v", err)
+		}
+	})
+
+	t.Run("accepts defaults", func(t *testing.T) {
+		// When
+		err := Default().Validate()
+
+		// Then
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
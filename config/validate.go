@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// ValidationError lists every invalid setting, so they can be fixed at once
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "Invalid config:\n  " + strings.Join(e, "\n  ")
+}
+
+type validator struct {
+	errors ValidationError
+}
+
+func (v *validator) check(ok bool, field string, format string, args ...interface{}) {
+	if !ok {
+		v.errors = append(v.errors, field+" "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) notNegative(field string, value float64) {
+	v.check(value >= 0, field, "must not be negative, got %g", value)
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return v.errors
+}
+
+func (c Config) validate(v *validator) {
+	// Sim settings are checked by sim, so its rules are kept in one place
+	if errs, ok := c.Sim.Validate().(sim.ConfigError); ok {
+		for _, err := range errs {
+			v.errors = append(v.errors, "sim."+err)
+		}
+	}
+
+	v.notNegative("api.maxDepth", float64(c.API.MaxDepth))
+	v.notNegative("api.maxCost", float64(c.API.MaxCost))
+	v.notNegative("api.rate", c.API.Rate)
+	v.notNegative("api.rateBurst", float64(c.API.RateBurst))
+	v.notNegative("api.timeout", float64(c.API.Timeout))
+
+	v.check(
+		!c.Tracing.Enabled || c.Tracing.AgentHost != "",
+		"tracing.agentHost",
+		"must be set if tracing is enabled",
+	)
+
+	v.notNegative("batch.iterations", float64(c.Batch.Iterations))
+	v.notNegative("batch.snapshotEvery", float64(c.Batch.SnapshotEvery))
+
+	v.notNegative("checkpoint.every", float64(c.Checkpoint.Every))
+	v.notNegative("checkpoint.interval", float64(c.Checkpoint.Interval))
+	v.notNegative("checkpoint.keep", float64(c.Checkpoint.Keep))
+}
+
+// Validate checks settings used in every mode
+func (c Config) Validate() error {
+	v := &validator{}
+	c.validate(v)
+
+	return v.err()
+}
+
+// ValidateServer checks settings required to serve the API as well
+func (c Config) ValidateServer() error {
+	v := &validator{}
+	c.validate(v)
+
+	v.check(
+		c.Server.Port > 0 && c.Server.Port < 1<<16,
+		"server.port",
+		"must be between 1 and 65535, got %d, set it in config file or PORT environment variable",
+		c.Server.Port,
+	)
+	v.check(
+		len(c.Server.AllowedOrigins) > 0,
+		"server.allowedOrigins",
+		"must not be empty, set it in config file or ALLOWED_ORIGINS environment variable",
+	)
+
+	return v.err()
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// Watch calls onChange whenever modification time of file changes, it's
+// checked at given interval until context is cancelled
+func Watch(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var modifiedAt time.Time
+	if info, err := os.Stat(path); err == nil {
+		modifiedAt = info.ModTime()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().Equal(modifiedAt) {
+				continue
+			}
+			modifiedAt = info.ModTime()
+			onChange()
+		}
+	}
+}
+
+func lowerFirst(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// GetRestartRequired returns settings which differ in next config, but can't
+// be applied without restart
+func (c Config) GetRestartRequired(next Config) []string {
+	names := []string{}
+
+	current := reflect.ValueOf(c.Sim)
+	nextSim := reflect.ValueOf(next.Sim)
+	for fieldIndex := 0; fieldIndex < current.NumField(); fieldIndex++ {
+		name := lowerFirst(current.Type().Field(fieldIndex).Name)
+		if sim.IsTunable(name) {
+			continue
+		}
+		// Values are compared, because some settings are pointers
+		if !reflect.DeepEqual(
+			current.Field(fieldIndex).Interface(),
+			nextSim.Field(fieldIndex).Interface(),
+		) {
+			names = append(names, "sim."+name)
+		}
+	}
+
+	sections := []struct {
+		name    string
+		current interface{}
+		next    interface{}
+	}{
+		{"server", c.Server, next.Server},
+		{"api", c.API, next.API},
+		{"tracing", c.Tracing, next.Tracing},
+		{"batch", c.Batch, next.Batch},
+		{"checkpoint", c.Checkpoint, next.Checkpoint},
+	}
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.current, section.next) {
+			names = append(names, section.name)
+		}
+	}
+
+	return names
+}
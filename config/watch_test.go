@@ -0,0 +1,70 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	// Given
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan struct{}, 1)
+	go Watch(ctx, path, time.Millisecond, func() { changed <- struct{}{} })
+
+	// When
+	// Let watcher read initial modification time first
+	time.Sleep(50 * time.Millisecond)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Error("Expected change to be noticed")
+	}
+}
+
+func TestGetRestartRequired(t *testing.T) {
+	// Given
+	current := Default()
+	next := Default()
+	currentRate, nextRate := .01, .01
+	current.Sim.MutationRate = &currentRate
+	next.Sim.MutationRate = &nextRate
+	next.Sim.MaxOrganisms = 5
+	next.Sim.Verbose = true
+	next.Sim.StartCells = 50
+	next.Server.Port = 9000
+
+	// When
+	names := current.GetRestartRequired(next)
+
+	// Then
+	expected := []string{"sim.startCells", "server"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for nameIndex := range expected {
+		if names[nameIndex] != expected[nameIndex] {
+			t.Errorf("Expected %s, got %s", expected[nameIndex], names[nameIndex])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package eventlog
+
+import (
+	"io"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// Lineage holds causality reconstructed from event log
+type Lineage struct {
+	// Parents maps organism ID to ID of organism it split off from
+	Parents map[int]int
+	// DeathCauses maps organism ID to reason of its death
+	DeathCauses map[int]sim.DeathCause
+	// SpeciesParents maps species ID to species it mutated from
+	SpeciesParents map[int]int
+}
+
+func GetLineage(r io.Reader) (Lineage, error) {
+	lineage := Lineage{
+		Parents:        map[int]int{},
+		DeathCauses:    map[int]sim.DeathCause{},
+		SpeciesParents: map[int]int{},
+	}
+
+	err := Read(r, func(event sim.Event) error {
+		switch event.Type {
+		case sim.EventBirth:
+			lineage.Parents[event.OrganismID] = getInt(event.Payload["parent"])
+		case sim.EventDeath:
+			if cause, ok := event.Payload["cause"].(string); ok {
+				lineage.DeathCauses[event.OrganismID] = sim.DeathCause(cause)
+			}
+		case sim.EventMutation:
+			lineage.SpeciesParents[event.SpeciesID] = getInt(event.Payload["parentSpecies"])
+		}
+
+		return nil
+	})
+
+	return lineage, err
+}
+
+// GetAncestors returns organism's ancestors, starting from its parent
+func (l Lineage) GetAncestors(id int) []int {
+	ancestors := []int{}
+	for {
+		parent, ok := l.Parents[id]
+		if !ok {
+			return ancestors
+		}
+		ancestors = append(ancestors, parent)
+		id = parent
+	}
+}
+
+// Payload values are decoded from JSON as float64
+func getInt(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
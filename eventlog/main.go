@@ -0,0 +1,139 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// Writer appends sim events to JSON Lines file, one event per line
+type Writer struct {
+	flushInterval time.Duration
+	done          chan struct{}
+
+	// Guards file, which is flushed in the background
+	lock    sync.Mutex
+	file    *os.File
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func New(path string, flushInterval time.Duration) (*Writer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("Event log path not set")
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bufio.NewWriter(file)
+	w := &Writer{
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+		file:          file,
+		buf:           buf,
+		encoder:       json.NewEncoder(buf),
+	}
+	if flushInterval > 0 {
+		go w.flushLoop()
+	}
+
+	return w, nil
+}
+
+func (w *Writer) flushLoop() {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := w.Flush(); err != nil {
+				log.Printf("Could not flush event log: %s", err)
+			}
+		}
+	}
+}
+
+// Write flushes event right away if flush interval is 0
+func (w *Writer) Write(event sim.Event) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.encoder.Encode(event); err != nil {
+		return err
+	}
+
+	if w.flushInterval == 0 {
+		return w.buf.Flush()
+	}
+
+	return nil
+}
+
+// OnEvent can be registered as sim event handler
+func (w *Writer) OnEvent(event sim.Event) {
+	if err := w.Write(event); err != nil {
+		log.Printf("Could not log %s event: %s", event.Type, err)
+	}
+}
+
+// OnStep can be registered as sim step handler to flush events after every
+// iteration
+func (w *Writer) OnStep(s *sim.Sim, data sim.IterationData) {
+	if err := w.Flush(); err != nil {
+		log.Printf("Could not flush event log: %s", err)
+	}
+}
+
+func (w *Writer) Flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.buf.Flush()
+}
+
+// Close stops background flushing, so it must be called once
+func (w *Writer) Close() error {
+	close(w.done)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// Read calls fn for every event in log, stopping on first error
+func Read(r io.Reader, fn func(event sim.Event) error) error {
+	decoder := json.NewDecoder(r)
+
+	for {
+		var event sim.Event
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
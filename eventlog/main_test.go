@@ -0,0 +1,131 @@
+package eventlog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func TestEventLog(t *testing.T) {
+	t.Run("appends events and reads them back", func(t *testing.T) {
+		// Given
+		dir, err := ioutil.TempDir("", "eventlog")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "events.jsonl")
+
+		events := []sim.Event{
+			{
+				Type:       sim.EventBirth,
+				Iteration:  1,
+				OrganismID: 2,
+				Payload:    map[string]interface{}{"parent": 1},
+			},
+			{
+				Type:       sim.EventDeath,
+				Iteration:  2,
+				OrganismID: 1,
+				Payload:    map[string]interface{}{"cause": sim.DeathCauseStarvation},
+			},
+		}
+
+		// When
+		for _, event := range events {
+			w, err := New(path, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.OnEvent(event)
+			w.Close()
+		}
+
+		// Then
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		lineage, err := GetLineage(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lineage.Parents[2] != 1 {
+			t.Errorf("Expected %d, got %d", 1, lineage.Parents[2])
+		}
+		if lineage.DeathCauses[1] != sim.DeathCauseStarvation {
+			t.Errorf("Expected %s, got %s", sim.DeathCauseStarvation, lineage.DeathCauses[1])
+		}
+	})
+
+	t.Run("flushes events at interval", func(t *testing.T) {
+		// Given
+		dir, err := ioutil.TempDir("", "eventlog")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "events.jsonl")
+
+		w, err := New(path, 10*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+
+		// When
+		w.OnEvent(sim.Event{Type: sim.EventBirth, Iteration: 1})
+		time.Sleep(50 * time.Millisecond)
+
+		// Then
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) == 0 {
+			t.Error("Expected event to be flushed")
+		}
+	})
+
+	t.Run("records sim lifecycle", func(t *testing.T) {
+		// Given
+		s := sim.Sim{}
+		s.Create(sim.SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			Seed:               42,
+			StartCells:         10,
+		})
+		counts := map[sim.EventType]int{}
+		births := map[int]int{}
+		s.OnEvent(func(event sim.Event) {
+			counts[event.Type]++
+			if event.Type == sim.EventBirth {
+				births[event.OrganismID] = event.Payload["parent"].(int)
+			}
+		})
+
+		// When
+		for it := 0; it < 200; it++ {
+			s.RunStep(context.TODO())
+		}
+
+		// Then
+		if counts[sim.EventBirth] == 0 {
+			t.Error("Expected birth events")
+		}
+		for id, parent := range births {
+			if parent >= id {
+				t.Errorf("Expected parent %d to be older than %d", parent, id)
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dominik-zeglen/aquarium/analytics"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type Row struct {
+	Data   sim.IterationData
+	Report *analytics.Report
+}
+
+type column struct {
+	// derived columns need analytics report to be computed
+	derived bool
+	value   func(row Row) interface{}
+}
+
+var DefaultColumns = []string{
+	"iteration",
+	"cellCount",
+	"aliveCellCount",
+	"toxicity",
+	"minTolerance",
+	"maxTolerance",
+	"minHeight",
+	"maxHeight",
+	"species",
+	"richness",
+	"shannon",
+	"simpson",
+	"evenness",
+}
+
+var columns = map[string]column{
+	"iteration":      {false, func(r Row) interface{} { return r.Data.Iteration }},
+	"cellCount":      {false, func(r Row) interface{} { return r.Data.CellCount }},
+	"aliveCellCount": {false, func(r Row) interface{} { return r.Data.AliveCellCount }},
+	"toxicity":       {false, func(r Row) interface{} { return r.Data.Waste.Waste }},
+	"minTolerance":   {false, func(r Row) interface{} { return r.Data.Waste.MinTolerance }},
+	"maxTolerance":   {false, func(r Row) interface{} { return r.Data.Waste.MaxTolerance }},
+	"canProcreate":   {false, func(r Row) interface{} { return r.Data.Procreation.CanProcreate }},
+	"minCd":          {false, func(r Row) interface{} { return r.Data.Procreation.MinCd }},
+	"maxCd":          {false, func(r Row) interface{} { return r.Data.Procreation.MaxCd }},
+	"minHeight":      {false, func(r Row) interface{} { return r.Data.Procreation.MinHeight }},
+	"maxHeight":      {false, func(r Row) interface{} { return r.Data.Procreation.MaxHeight }},
+	"species":        {false, func(r Row) interface{} { return len(r.Data.Procreation.Species) }},
+
+	"richness": {true, func(r Row) interface{} { return r.Report.Diversity.Richness }},
+	"shannon":  {true, func(r Row) interface{} { return r.Report.Diversity.Shannon }},
+	"simpson":  {true, func(r Row) interface{} { return r.Report.Diversity.Simpson }},
+	"evenness": {true, func(r Row) interface{} { return r.Report.Diversity.Evenness }},
+}
+
+func init() {
+	for traitIndex, trait := range analytics.Traits {
+		i := traitIndex
+		columns[string(trait)+".min"] = column{true, func(r Row) interface{} {
+			return r.Report.Traits[i].Min
+		}}
+		columns[string(trait)+".max"] = column{true, func(r Row) interface{} {
+			return r.Report.Traits[i].Max
+		}}
+		columns[string(trait)+".mean"] = column{true, func(r Row) interface{} {
+			return r.Report.Traits[i].Mean
+		}}
+	}
+
+	for dietIndex, diet := range analytics.Diets {
+		i := dietIndex
+		columns["biomass."+diet.String()] = column{true, func(r Row) interface{} {
+			return r.Report.Biomass[i].Biomass
+		}}
+	}
+}
+
+func GetColumnNames() []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func getColumns(names []string) ([]column, error) {
+	cols := make([]column, len(names))
+
+	for nameIndex, name := range names {
+		col, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown column %s", name)
+		}
+		cols[nameIndex] = col
+	}
+
+	return cols, nil
+}
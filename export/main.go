@@ -0,0 +1,267 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/analytics"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type Format string
+
+const (
+	FormatCSV   = Format("csv")
+	FormatJSONL = Format("jsonl")
+)
+
+type Config struct {
+	Path    string
+	Format  Format
+	Columns []string
+	// Start new file after this number of rows, 0 disables rotation
+	RotateRows int
+	// Rows are flushed to file at this interval, or after each one if 0
+	FlushInterval time.Duration
+}
+
+type Exporter struct {
+	config  Config
+	columns []column
+	derived bool
+	done    chan struct{}
+
+	// Guards file, which is flushed in the background
+	lock      sync.Mutex
+	file      *os.File
+	buf       *bufio.Writer
+	rows      int
+	fileIndex int
+}
+
+func New(config Config) (*Exporter, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("Export path not set")
+	}
+	if config.Format == "" {
+		config.Format = FormatCSV
+		if strings.HasSuffix(config.Path, ".jsonl") {
+			config.Format = FormatJSONL
+		}
+	}
+	if config.Format != FormatCSV && config.Format != FormatJSONL {
+		return nil, fmt.Errorf("Unknown export format %s", config.Format)
+	}
+	if len(config.Columns) == 0 {
+		config.Columns = DefaultColumns
+	}
+
+	cols, err := getColumns(config.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		config:  config,
+		columns: cols,
+		done:    make(chan struct{}),
+	}
+	for _, col := range cols {
+		e.derived = e.derived || col.derived
+	}
+
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+	if config.FlushInterval > 0 {
+		go e.flushLoop()
+	}
+
+	return e, nil
+}
+
+func (e *Exporter) flushLoop() {
+	ticker := time.NewTicker(e.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+			if err := e.Flush(); err != nil {
+				log.Printf("Could not flush exported metrics: %s", err)
+			}
+		}
+	}
+}
+
+func (e *Exporter) getFilePath() string {
+	if e.config.RotateRows == 0 {
+		return e.config.Path
+	}
+
+	ext := filepath.Ext(e.config.Path)
+	base := strings.TrimSuffix(e.config.Path, ext)
+
+	return fmt.Sprintf("%s.%04d%s", base, e.fileIndex, ext)
+}
+
+func (e *Exporter) open() error {
+	file, err := os.Create(e.getFilePath())
+	if err != nil {
+		return err
+	}
+
+	e.file = file
+	e.buf = bufio.NewWriter(file)
+	e.rows = 0
+
+	if e.config.Format == FormatCSV {
+		return e.writeCSV(e.config.Columns)
+	}
+
+	return nil
+}
+
+func (e *Exporter) closeFile() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+
+	return e.file.Close()
+}
+
+func (e *Exporter) rotate() error {
+	if err := e.closeFile(); err != nil {
+		return err
+	}
+	e.fileIndex++
+
+	return e.open()
+}
+
+func (e *Exporter) writeCSV(record []string) error {
+	w := csv.NewWriter(e.buf)
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// writeJSONL keeps keys in order of columns. Row is encoded as a whole before
+// it's written, so a value which can't be encoded doesn't leave it half written.
+func (e *Exporter) writeJSONL(row Row) error {
+	line := bytes.Buffer{}
+	line.WriteByte('{')
+	for colIndex, col := range e.columns {
+		if colIndex > 0 {
+			line.WriteByte(',')
+		}
+
+		key, err := json.Marshal(e.config.Columns[colIndex])
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(col.value(row))
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+
+	_, err := line.WriteTo(e.buf)
+
+	return err
+}
+
+func (e *Exporter) WriteRow(row Row) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.config.RotateRows > 0 && e.rows >= e.config.RotateRows {
+		if err := e.rotate(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if e.config.Format == FormatCSV {
+		record := make([]string, len(e.columns))
+		for colIndex, col := range e.columns {
+			record[colIndex] = formatValue(col.value(row))
+		}
+		err = e.writeCSV(record)
+	} else {
+		err = e.writeJSONL(row)
+	}
+	if err != nil {
+		return err
+	}
+	e.rows++
+
+	if e.config.FlushInterval == 0 {
+		return e.buf.Flush()
+	}
+
+	return nil
+}
+
+// Write must be called while holding sim lock
+func (e *Exporter) Write(s *sim.Sim, data sim.IterationData) error {
+	row := Row{Data: data}
+	if e.derived {
+		report := analytics.Compute(s)
+		row.Report = &report
+	}
+
+	return e.WriteRow(row)
+}
+
+// OnStep can be registered as sim step handler
+func (e *Exporter) OnStep(s *sim.Sim, data sim.IterationData) {
+	if err := e.Write(s, data); err != nil {
+		log.Printf("Could not export iteration %d: %s", data.Iteration, err)
+	}
+}
+
+func (e *Exporter) Flush() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.buf.Flush()
+}
+
+// Close stops background flushing, so it must be called once
+func (e *Exporter) Close() error {
+	close(e.done)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.closeFile()
+}
@@ -0,0 +1,179 @@
+package export
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func getTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestCSVExport(t *testing.T) {
+	// Given
+	dir := getTempDir(t)
+	defer os.RemoveAll(dir)
+
+	e, err := New(Config{
+		Path:       filepath.Join(dir, "metrics.csv"),
+		Columns:    []string{"iteration", "cellCount"},
+		RotateRows: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	for it := 1; it <= 3; it++ {
+		err := e.WriteRow(Row{Data: sim.IterationData{Iteration: it, CellCount: 10}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	e.Close()
+
+	// Then
+	first, err := ioutil.ReadFile(filepath.Join(dir, "metrics.0000.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "iteration,cellCount\n1,10\n2,10\n"
+	if string(first) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(first))
+	}
+
+	second, err := ioutil.ReadFile(filepath.Join(dir, "metrics.0001.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "iteration,cellCount\n3,10\n"
+	if string(second) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(second))
+	}
+}
+
+func TestJSONLExport(t *testing.T) {
+	// Given
+	dir := getTempDir(t)
+	defer os.RemoveAll(dir)
+
+	s := sim.Sim{}
+	s.Create(sim.SimConfig{StartCells: 3})
+	e, err := New(Config{
+		Path:    filepath.Join(dir, "metrics.jsonl"),
+		Columns: []string{"richness", "iteration", "size.mean"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	err = e.Write(&s, sim.IterationData{Iteration: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Close()
+
+	// Then
+	data, err := ioutil.ReadFile(filepath.Join(dir, "metrics.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `{"richness":3,"iteration":1,"size.mean":`) {
+		t.Errorf("Unexpected output %q", string(data))
+	}
+}
+
+func TestJSONLInvalidValue(t *testing.T) {
+	// Given
+	dir := getTempDir(t)
+	defer os.RemoveAll(dir)
+
+	e, err := New(Config{
+		Path:    filepath.Join(dir, "metrics.jsonl"),
+		Columns: []string{"iteration", "minHeight"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := sim.IterationData{Iteration: 1}
+	invalid.Procreation.MinHeight = math.NaN()
+
+	// When
+	invalidErr := e.WriteRow(Row{Data: invalid})
+	err = e.WriteRow(Row{Data: sim.IterationData{Iteration: 2}})
+	e.Close()
+
+	// Then
+	if invalidErr == nil {
+		t.Error("Expected error")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "metrics.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\"iteration\":2,\"minHeight\":0}\n"
+	if string(data) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(data))
+	}
+}
+
+func TestFlushInterval(t *testing.T) {
+	// Given
+	dir := getTempDir(t)
+	defer os.RemoveAll(dir)
+
+	e, err := New(Config{
+		Path:          filepath.Join(dir, "metrics.csv"),
+		Columns:       []string{"iteration"},
+		FlushInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// When
+	if err := e.WriteRow(Row{Data: sim.IterationData{Iteration: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// Then
+	data, err := ioutil.ReadFile(filepath.Join(dir, "metrics.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "iteration\n1\n"
+	if string(data) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(data))
+	}
+}
+
+func TestUnknownColumn(t *testing.T) {
+	// When
+	_, err := New(Config{
+		Path:    "metrics.csv",
+		Columns: []string{"foo"},
+	})
+
+	// Then
+	if err == nil {
+		t.Error("Expected error")
+	}
+}
@@ -5,12 +5,15 @@ go 1.12
 require (
 	github.com/chobie/go-gaussian v0.0.0-20150107165016-53c09d90eeaf
 	github.com/golang/geo v0.0.0-20200730024412-e86565bf3f35
+	github.com/gorilla/websocket v1.5.3
 	github.com/graph-gophers/graphql-go v0.0.0-20201113091052-beb923fada29
 	github.com/opentracing/opentracing-go v1.1.0
 	github.com/pkg/errors v0.9.1 // indirect
+	github.com/prometheus/client_golang v1.11.1
 	github.com/rs/cors v1.7.0
 	github.com/uber/jaeger-client-go v2.25.0+incompatible
 	github.com/uber/jaeger-lib v2.4.0+incompatible
 	github.com/urfave/cli/v2 v2.3.0 // indirect
+	github.com/vektah/gqlparser/v2 v2.1.0
 	go.uber.org/atomic v1.7.0 // indirect
 )
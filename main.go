@@ -1,22 +1,56 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/dominik-zeglen/aquarium/api"
+	"github.com/dominik-zeglen/aquarium/batch"
+	"github.com/dominik-zeglen/aquarium/checkpoint"
+	"github.com/dominik-zeglen/aquarium/config"
+	"github.com/dominik-zeglen/aquarium/eventlog"
+	"github.com/dominik-zeglen/aquarium/export"
+	"github.com/dominik-zeglen/aquarium/metrics"
 	"github.com/dominik-zeglen/aquarium/middleware"
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/render"
 	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/dominik-zeglen/aquarium/stream"
+	"github.com/dominik-zeglen/aquarium/sweep"
+	"github.com/dominik-zeglen/aquarium/timelapse"
 	"github.com/dominik-zeglen/aquarium/tracing"
+	"github.com/dominik-zeglen/aquarium/tui"
+	"github.com/dominik-zeglen/aquarium/web"
 	"github.com/opentracing/opentracing-go"
 )
 
+// Flag defaults are the lowest precedence settings, so that help shows them
+var defaults = config.Default()
+
+var configPath = flag.String(
+	"c",
+	"",
+	"Read settings from this JSON file, environment variables and flags take precedence",
+)
+var configWatch = flag.Duration(
+	"cw",
+	0,
+	"Check config file for changes at this interval and apply tunable sim settings, 0 disables reload",
+)
 var maxOrganisms = flag.Int(
 	"m",
-	1e3,
+	defaults.Sim.MaxOrganisms,
 	"Maximum organisms in the whole sim",
 )
 var verbose = flag.Bool(
@@ -26,22 +60,22 @@ var verbose = flag.Bool(
 )
 var maxCellsInOrganism = flag.Int(
 	"mo",
-	25,
+	defaults.Sim.MaxCellsInOrganism,
 	"Maximum cells in one organism",
 )
 var envDivisions = flag.Int(
 	"d",
-	4,
+	defaults.Sim.EnvDivisions,
 	"Divide environment along and across by this number",
 )
 var warmupIterations = flag.Int(
 	"w",
-	5e4,
+	defaults.Sim.WarmupIterations,
 	"Do not pause sim until number of this iterations has been reached",
 )
 var startCells = flag.Int(
 	"s",
-	10,
+	defaults.Sim.StartCells,
 	"Number of cells created at the start of the sim",
 )
 var trace = flag.Bool(
@@ -50,61 +84,670 @@ var trace = flag.Bool(
 	"Enable tracing",
 )
 
-func getConfig() sim.SimConfig {
-	return sim.SimConfig{
-		EnvDivisions:       *envDivisions,
-		MaxCellsInOrganism: *maxCellsInOrganism,
-		MaxOrganisms:       *maxOrganisms,
-		StartCells:         *startCells,
-		Verbose:            *verbose,
-		WarmupIterations:   *warmupIterations,
+var batchMode = flag.Bool(
+	"b",
+	false,
+	"Run headless batch without HTTP server",
+)
+var uiMode = flag.Bool(
+	"ui",
+	false,
+	"Show simulation in terminal without HTTP server",
+)
+var uiDelay = flag.Duration(
+	"ud",
+	0,
+	"Wait this long between steps shown in terminal",
+)
+var sweepPath = flag.String(
+	"sweep",
+	"",
+	"Run parameter sweep defined in this file without HTTP server",
+)
+var seed = flag.Int64(
+	"seed",
+	0,
+	"Seed random generator with this value, 0 uses random seed",
+)
+var iterations = flag.Int(
+	"i",
+	0,
+	"Stop batch after this number of iterations, 0 runs until extinction",
+)
+var outputDir = flag.String(
+	"o",
+	"",
+	"Write batch summary and snapshots to this directory",
+)
+var snapshotEvery = flag.Int(
+	"ss",
+	0,
+	"Write batch snapshot every this number of iterations, 0 disables snapshots",
+)
+
+// Batch exit codes
+const (
+	exitSurvived = 0
+	exitError    = 1
+	exitExtinct  = 2
+)
+
+var exportPath = flag.String(
+	"x",
+	"",
+	"Export per-iteration metrics to this file",
+)
+var exportFormat = flag.String(
+	"xf",
+	"",
+	"Export format, csv or jsonl, defaults to file extension",
+)
+var exportColumns = flag.String(
+	"xc",
+	strings.Join(export.DefaultColumns, ","),
+	"Comma separated list of exported columns, available: "+
+		strings.Join(export.GetColumnNames(), ", "),
+)
+var exportRotate = flag.Int(
+	"xr",
+	0,
+	"Start new export file after this number of iterations, 0 disables rotation",
+)
+var exportFlush = flag.Duration(
+	"xi",
+	5*time.Second,
+	"Flush exported metrics to file at this interval",
+)
+var timelapsePath = flag.String(
+	"tl",
+	"",
+	"Record timelapse GIF of the simulation to this file",
+)
+var timelapseEvery = flag.Int(
+	"tle",
+	timelapse.DefaultEvery,
+	"Capture timelapse frame every this number of iterations",
+)
+var timelapseStart = flag.Int(
+	"tls",
+	0,
+	"Start timelapse at this iteration",
+)
+var timelapseEnd = flag.Int(
+	"tln",
+	0,
+	"End timelapse at this iteration, 0 records until exit",
+)
+var timelapseWindow = flag.Int(
+	"tlf",
+	0,
+	"Keep only this number of the latest timelapse frames, 0 keeps all",
+)
+var timelapseWidth = flag.Int(
+	"tlw",
+	render.DefaultWidth,
+	"Width of timelapse frames",
+)
+var timelapseScheme = flag.String(
+	"tlc",
+	string(render.SchemeSpecies),
+	"Colour scheme of timelapse frames, available: age, diet, species, toxicity",
+)
+var keysPath = flag.String(
+	"keys",
+	"",
+	"Read API keys and their roles from this file, API is open to anyone if empty",
+)
+var queryMaxDepth = flag.Int(
+	"qd",
+	defaults.API.MaxDepth,
+	"Reject GraphQL queries nested deeper than this, 0 disables limit",
+)
+var queryMaxCost = flag.Int(
+	"qc",
+	defaults.API.MaxCost,
+	"Reject GraphQL queries estimated to resolve more fields than this, 0 disables limit",
+)
+var queryRate = flag.Float64(
+	"qr",
+	defaults.API.Rate,
+	"Allow this many GraphQL requests per second for single client, 0 disables limit",
+)
+var queryBurst = flag.Int(
+	"qb",
+	defaults.API.RateBurst,
+	"Allow bursts of this many GraphQL requests for single client",
+)
+var queryTimeout = flag.Duration(
+	"qt",
+	time.Duration(defaults.API.Timeout),
+	"Cancel GraphQL queries and mutations running longer than this, 0 disables limit",
+)
+var eventLogPath = flag.String(
+	"el",
+	"",
+	"Append births, deaths, mutations and decay events to this file",
+)
+var eventLogFlush = flag.Duration(
+	"elf",
+	5*time.Second,
+	"Flush logged events to file at this interval",
+)
+var checkpointDir = flag.String(
+	"cpd",
+	"",
+	"Save default simulation to this directory on shutdown and resume from the newest checkpoint on start",
+)
+var checkpointEvery = flag.Int(
+	"cpe",
+	0,
+	"Save checkpoint every this number of iterations, 0 disables it",
+)
+var checkpointInterval = flag.Duration(
+	"cpi",
+	0,
+	"Save checkpoint at this interval, 0 disables it",
+)
+var checkpointKeep = flag.Int(
+	"cpk",
+	defaults.Checkpoint.Keep,
+	"Keep only this number of the newest checkpoints, 0 keeps all",
+)
+
+var settings config.Config
+
+// getSettings merges defaults, config file, environment variables and flags
+// set explicitly, in order of increasing precedence
+func getSettings() (config.Config, error) {
+	c := defaults
+	if *configPath != "" {
+		var err error
+		if c, err = config.LoadFile(*configPath, c); err != nil {
+			return c, err
+		}
+	}
+
+	c, err := config.FromEnv(c, os.Getenv)
+	if err != nil {
+		return c, err
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "m":
+			c.Sim.MaxOrganisms = *maxOrganisms
+		case "v":
+			c.Sim.Verbose = *verbose
+		case "mo":
+			c.Sim.MaxCellsInOrganism = *maxCellsInOrganism
+		case "d":
+			c.Sim.EnvDivisions = *envDivisions
+		case "w":
+			c.Sim.WarmupIterations = *warmupIterations
+		case "s":
+			c.Sim.StartCells = *startCells
+		case "seed":
+			c.Sim.Seed = *seed
+		case "t":
+			c.Tracing.Enabled = *trace
+		case "i":
+			c.Batch.Iterations = *iterations
+		case "o":
+			c.Batch.OutputDir = *outputDir
+		case "ss":
+			c.Batch.SnapshotEvery = *snapshotEvery
+		case "keys":
+			c.Server.Keys = *keysPath
+		case "qd":
+			c.API.MaxDepth = *queryMaxDepth
+		case "qc":
+			c.API.MaxCost = *queryMaxCost
+		case "qr":
+			c.API.Rate = *queryRate
+		case "qb":
+			c.API.RateBurst = *queryBurst
+		case "qt":
+			c.API.Timeout = config.Duration(*queryTimeout)
+		case "cpd":
+			c.Checkpoint.Dir = *checkpointDir
+		case "cpe":
+			c.Checkpoint.Every = *checkpointEvery
+		case "cpi":
+			c.Checkpoint.Interval = config.Duration(*checkpointInterval)
+		case "cpk":
+			c.Checkpoint.Keep = *checkpointKeep
+		}
+	})
+
+	return c, nil
+}
+
+func getExportConfig() export.Config {
+	return export.Config{
+		Path:          *exportPath,
+		Format:        export.Format(*exportFormat),
+		Columns:       strings.Split(*exportColumns, ","),
+		RotateRows:    *exportRotate,
+		FlushInterval: *exportFlush,
+	}
+}
+
+func getTimelapseConfig() timelapse.Config {
+	return timelapse.Config{
+		Path:   *timelapsePath,
+		Every:  *timelapseEvery,
+		Start:  *timelapseStart,
+		End:    *timelapseEnd,
+		Window: *timelapseWindow,
+		Render: render.Options{
+			Width:  *timelapseWidth,
+			Scheme: render.Scheme(*timelapseScheme),
+		},
+	}
+}
+
+func getCheckpointConfig() checkpoint.Config {
+	return checkpoint.Config{
+		Dir:      settings.Checkpoint.Dir,
+		Every:    settings.Checkpoint.Every,
+		Interval: time.Duration(settings.Checkpoint.Interval),
+		Keep:     settings.Checkpoint.Keep,
+	}
+}
+
+func getBatchConfig() batch.Config {
+	config := batch.Config{
+		Sim:           settings.Sim,
+		Iterations:    settings.Batch.Iterations,
+		OutputDir:     settings.Batch.OutputDir,
+		SnapshotEvery: settings.Batch.SnapshotEvery,
+		EventLog:      *eventLogPath,
+		EventLogFlush: *eventLogFlush,
+	}
+	if *exportPath != "" {
+		config.Export = getExportConfig()
+	}
+	if *timelapsePath != "" {
+		config.Timelapse = getTimelapseConfig()
+	}
+
+	return config
+}
+
+func runBatch() int {
+	config := getBatchConfig()
+	if config.Sim.Seed == 0 {
+		config.Sim.Seed = time.Now().UnixNano()
 	}
+
+	summary, err := batch.Run(context.Background(), config)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	fmt.Printf(
+		"Seed: %d, outcome: %s, it: %d, o: %d, sp: %d, w: %.4f, took: %.2fs\n",
+		summary.Seed,
+		summary.Outcome,
+		summary.Iterations,
+		summary.Organisms,
+		summary.Species,
+		summary.Toxicity,
+		summary.Duration,
+	)
+
+	if summary.Outcome == batch.OutcomeExtinct {
+		return exitExtinct
+	}
+
+	return exitSurvived
 }
 
-func checkEnvVar(key string) error {
-	if os.Getenv(key) == "" {
-		return fmt.Errorf("Environment variable %s not set", key)
+func runViewer() int {
+	simConfig := settings.Sim
+	// Verbose lines are shown by viewer instead
+	simConfig.Verbose = false
+	if simConfig.Seed == 0 {
+		simConfig.Seed = time.Now().UnixNano()
+	}
+
+	s := &sim.Sim{}
+	s.Create(simConfig)
+
+	if err := tui.Run(context.Background(), s, tui.Config{Delay: *uiDelay}); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return exitSurvived
+}
+
+func runSweep() int {
+	file, err := os.Open(*sweepPath)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+	space, err := sweep.Load(file)
+	file.Close()
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	results := sweep.Execute(context.Background(), space)
+
+	var w io.Writer = os.Stdout
+	if settings.Batch.OutputDir != "" {
+		if err := os.MkdirAll(settings.Batch.OutputDir, 0755); err != nil {
+			log.Println(err)
+			return exitError
+		}
+		resultsFile, err := os.Create(filepath.Join(settings.Batch.OutputDir, "results.csv"))
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		defer resultsFile.Close()
+		w = resultsFile
 	}
 
-	return nil
+	if err := sweep.WriteCSV(w, space, results); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return exitSurvived
+}
+
+// watchSettings applies tunable sim settings of config file to s whenever
+// file changes, the rest of them is ignored until restart
+func watchSettings(ctx context.Context, s *sim.Sim) {
+	current := settings
+
+	config.Watch(ctx, *configPath, *configWatch, func() {
+		next, err := getSettings()
+		if err == nil {
+			err = next.ValidateServer()
+		}
+		if err != nil {
+			log.Printf("Config not reloaded: %s", err)
+			return
+		}
+
+		for _, name := range current.GetRestartRequired(next) {
+			log.Printf("Config setting %s changed, it's applied after restart", name)
+		}
+
+		_, err = s.Exec(ctx, func(s *sim.Sim) error {
+			changes, err := s.Tune(next.Sim)
+			for _, change := range changes {
+				log.Printf("Simulation %s tuned, %s", registry.DefaultID, change)
+			}
+
+			return err
+		})
+		if err != nil {
+			log.Printf("Config not reloaded: %s", err)
+			return
+		}
+
+		current = next
+	})
 }
 
 func init() {
 	flag.Parse()
-	envVars := []string{"ALLOWED_ORIGINS", "PORT"}
 
-	for _, envVar := range envVars {
-		err := checkEnvVar(envVar)
+	var err error
+	settings, err = getSettings()
+	if err == nil {
+		if *batchMode || *sweepPath != "" || *uiMode {
+			err = settings.Validate()
+		} else {
+			err = settings.ValidateServer()
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Requests still running after this time are dropped on shutdown, it leaves
+// time for checkpoint before docker kills the process
+const shutdownTimeout = 5 * time.Second
+
+// shutdown stops simulations after their current step, saves default one if
+// checkpointer is set, saves timelapses and waits for running requests to
+// finish
+func shutdown(
+	server *http.Server,
+	simulations *registry.Registry,
+	checkpointer *checkpoint.Checkpointer,
+	timelapses *timelapse.Store,
+) {
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Could not finish requests: %s", err)
+		}
+	}()
+
+	simulations.StopAll()
+
+	if checkpointer != nil {
+		// Periodic checkpoint still being written must not be pruned by the
+		// final one
+		checkpointer.Close()
+		simulation, err := simulations.Get(registry.DefaultID)
+		if err == nil {
+			// Draining mutations may still change sim, so it's saved as a command
+			var path string
+			_, err = simulation.Sim.Exec(context.Background(), func(s *sim.Sim) (err error) {
+				path, err = checkpointer.Save(s)
+				return err
+			})
+			if err == nil {
+				log.Printf("Simulation %s saved to %s", registry.DefaultID, path)
+			}
+		}
 		if err != nil {
-			panic(err)
+			log.Printf("Could not save checkpoint: %s", err)
 		}
 	}
+
+	if err := timelapses.Close(); err != nil {
+		log.Printf("Could not save timelapse: %s", err)
+	}
+
+	<-drained
 }
 
-func main() {
-	if os.Getenv("JAEGER_AGENT_HOST") != "" && *trace {
-		tracer, closer := tracing.InitJaeger()
+// resume loads the newest checkpoint of default simulation, then applies
+// tunable settings to it, the rest of them is read from checkpoint
+func resume(simulation *registry.Simulation) error {
+	path, err := checkpoint.Restore(settings.Checkpoint.Dir, simulation.Load)
+	if err != nil || path == "" {
+		return err
+	}
+	log.Printf(
+		"Simulation %s resumed from %s at iteration %d",
+		simulation.ID,
+		path,
+		simulation.GetIteration(),
+	)
+
+	_, err = simulation.Sim.Exec(context.Background(), func(s *sim.Sim) error {
+		changes, err := s.Tune(settings.Sim)
+		for _, change := range changes {
+			log.Printf("Simulation %s tuned, %s", simulation.ID, change)
+		}
+
+		return err
+	})
+
+	return err
+}
+
+func runServer() int {
+	if settings.Tracing.Enabled {
+		tracer, closer := tracing.InitJaeger(settings.Tracing.AgentHost)
 		opentracing.SetGlobalTracer(tracer)
 		defer closer.Close()
 	}
 
-	s := sim.Sim{}
-	config := getConfig()
-	s.Create(config)
+	simulations := registry.New(settings.Sim)
+	simulation, err := simulations.Create(registry.DefaultID, settings.Sim)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	var checkpointer *checkpoint.Checkpointer
+	if settings.Checkpoint.Dir != "" {
+		if err := resume(simulation); err != nil {
+			log.Println(err)
+			return exitError
+		}
+
+		checkpointer, err = checkpoint.New(getCheckpointConfig())
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		simulation.Sim.Observe(checkpointer)
+	}
+
+	if *exportPath != "" {
+		exporter, err := export.New(getExportConfig())
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		defer exporter.Close()
+		simulation.Sim.OnStep(exporter.OnStep)
+	}
+
+	if *eventLogPath != "" {
+		eventLog, err := eventlog.New(*eventLogPath, *eventLogFlush)
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		defer eventLog.Close()
+		simulation.Sim.OnEvent(eventLog.OnEvent)
+	}
+
+	timelapses := timelapse.NewStore()
+	if *timelapsePath != "" {
+		_, err := timelapses.Record(registry.DefaultID, simulation.Sim, getTimelapseConfig())
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+	}
+
+	withAuth := func(next http.Handler) http.Handler { return next }
+	if settings.Server.Keys != "" {
+		keys, err := middleware.LoadKeys(settings.Server.Keys)
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		withAuth = func(next http.Handler) http.Handler {
+			return middleware.WithAuth(keys, next)
+		}
+	} else {
+		log.Println("No API keys file given, API is open to anyone")
+	}
 
-	var data sim.IterationData
-	http.Handle("/api",
+	allowedOrigins := settings.Server.AllowedOrigins
+	mux := http.NewServeMux()
+	mux.Handle("/api",
 		middleware.WithTracing(
-			middleware.WithSim(
+			metrics.WithMetrics(
 				middleware.WithCors(
-					strings.Split(os.Getenv("ALLOWED_ORIGINS"), ","),
-					api.InitAPI(&s, &data),
+					allowedOrigins,
+					withAuth(
+						api.InitAPI(
+							simulations,
+							timelapses,
+							middleware.CheckOrigin(allowedOrigins),
+							settings.GetLimits(),
+						),
+					),
 				),
-				&s,
 			),
 		),
 	)
-	go http.ListenAndServe(":"+os.Getenv("PORT"), nil)
 
-	s.RunLoop(&data)
+	events := stream.NewBroker(stream.DefaultHistorySize)
+	simulation.Sim.OnStep(events.OnStep)
+	mux.Handle("/events", middleware.WithCors(allowedOrigins, withAuth(events)))
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle(
+		"/render",
+		middleware.WithCors(allowedOrigins, withAuth(render.Handler(simulations))),
+	)
+	mux.Handle("/timelapse", middleware.WithCors(allowedOrigins, withAuth(timelapses)))
+	// Viewer is public, it passes apiKey from its URL to the API
+	mux.Handle("/", web.Handler())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if *configPath != "" && *configWatch > 0 {
+		go watchSettings(ctx, simulation.Sim)
+	}
+
+	if err := simulation.Start(); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(settings.Server.Port),
+		Handler: mux,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	exitCode := exitSurvived
+	select {
+	case sig := <-signals:
+		log.Printf("Received %s, shutting down", sig)
+	case err := <-serverErr:
+		log.Printf("Server failed: %s", err)
+		exitCode = exitError
+	}
+
+	// Settings must not be applied while simulation is being saved
+	cancel()
+	shutdown(server, simulations, checkpointer, timelapses)
+
+	return exitCode
+}
+
+func main() {
+	if *sweepPath != "" {
+		os.Exit(runSweep())
+	}
+	if *batchMode {
+		os.Exit(runBatch())
+	}
+	if *uiMode {
+		os.Exit(runViewer())
+	}
+
+	os.Exit(runServer())
 }
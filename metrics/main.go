@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "aquarium"
+
+var simLabels = []string{"simulation"}
+
+var (
+	iteration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "iteration",
+		Help:      "Number of the last finished step",
+	}, simLabels)
+	organisms = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "organisms",
+		Help:      "Number of organisms, including dead ones not yet decayed",
+	}, simLabels)
+	aliveCells = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "alive_cells",
+		Help:      "Number of alive cells",
+	}, simLabels)
+	species = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "species",
+		Help:      "Number of species",
+	}, simLabels)
+	toxicity = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "toxicity",
+		Help:      "Environment toxicity",
+	}, simLabels)
+	minWasteTolerance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "waste_tolerance_min",
+		Help:      "Lowest waste tolerance among species",
+	}, simLabels)
+	maxWasteTolerance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "waste_tolerance_max",
+		Help:      "Highest waste tolerance among species",
+	}, simLabels)
+
+	stepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "step_duration_seconds",
+		Help:      "Time spent running single step",
+		Buckets:   prometheus.ExponentialBuckets(.001, 2, 15),
+	}, simLabels)
+	phaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "step_phase_duration_seconds",
+		Help:      "Time spent in phase of single step",
+		Buckets:   prometheus.ExponentialBuckets(.0001, 2, 18),
+	}, []string{"simulation", "phase"})
+
+	commandWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "command_wait_seconds",
+		Help:      "Time API mutations spent waiting for sim to apply them",
+		Buckets:   prometheus.ExponentialBuckets(.0001, 2, 18),
+	})
+	requestDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_request_duration_seconds",
+		Help:      "GraphQL request latency",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		iteration,
+		organisms,
+		aliveCells,
+		species,
+		toxicity,
+		minWasteTolerance,
+		maxWasteTolerance,
+		stepDuration,
+		phaseDuration,
+		commandWait,
+		requestDuration,
+	)
+}
+
+// Handler serves metrics in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// OnStep returns sim step handler updating metrics of given simulation
+func OnStep(id string) sim.StepHandler {
+	return func(s *sim.Sim, data sim.IterationData) {
+		iteration.WithLabelValues(id).Set(float64(data.Iteration))
+		organisms.WithLabelValues(id).Set(float64(data.CellCount))
+		aliveCells.WithLabelValues(id).Set(float64(data.AliveCellCount))
+		species.WithLabelValues(id).Set(float64(len(data.Procreation.Species)))
+		toxicity.WithLabelValues(id).Set(s.GetEnvironment().GetToxicity())
+		minWasteTolerance.WithLabelValues(id).Set(data.Waste.MinTolerance)
+		maxWasteTolerance.WithLabelValues(id).Set(data.Waste.MaxTolerance)
+
+		durations := s.GetStepDurations()
+		stepDuration.WithLabelValues(id).Observe(durations.Step.Seconds())
+		phaseDuration.WithLabelValues(id, "get-areas").Observe(durations.GetAreas.Seconds())
+		phaseDuration.WithLabelValues(id, "sim").Observe(durations.Sim.Seconds())
+		phaseDuration.WithLabelValues(id, "cleanup-species").Observe(durations.CleanupSpecies.Seconds())
+	}
+}
+
+// Forget removes metrics of deleted simulation
+func Forget(id string) {
+	for _, gauge := range []*prometheus.GaugeVec{
+		iteration,
+		organisms,
+		aliveCells,
+		species,
+		toxicity,
+		minWasteTolerance,
+		maxWasteTolerance,
+	} {
+		gauge.DeleteLabelValues(id)
+	}
+
+	stepDuration.DeleteLabelValues(id)
+	for _, phase := range []string{"get-areas", "sim", "cleanup-species"} {
+		phaseDuration.DeleteLabelValues(id, phase)
+	}
+}
+
+func ObserveCommandWait(duration time.Duration) {
+	commandWait.Observe(duration.Seconds())
+}
+
+// WithMetrics measures latency of GraphQL requests, WebSocket connections
+// are skipped since they live as long as client is connected
+func WithMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			next.ServeHTTP(w, r)
+			requestDuration.Observe(time.Since(start).Seconds())
+		},
+	)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func getTestSim() *sim.Sim {
+	s := sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+
+	return &s
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("updates metrics on step", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		s.OnStep(OnStep("test"))
+
+		// When
+		s.Lock()
+		data := s.RunStep(context.TODO())
+		s.Unlock()
+
+		// Then
+		value := testutil.ToFloat64(iteration.WithLabelValues("test"))
+		if value != float64(data.Iteration) {
+			t.Errorf("Expected %d, got %f", data.Iteration, value)
+		}
+		value = testutil.ToFloat64(aliveCells.WithLabelValues("test"))
+		if value != float64(data.AliveCellCount) {
+			t.Errorf("Expected %d, got %f", data.AliveCellCount, value)
+		}
+	})
+
+	t.Run("serves metrics of simulation", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		s.OnStep(OnStep("served"))
+		s.Lock()
+		s.RunStep(context.TODO())
+		s.Unlock()
+		recorder := httptest.NewRecorder()
+
+		// When
+		Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		// Then
+		body, _ := ioutil.ReadAll(recorder.Body)
+		expected := `aquarium_step_phase_duration_seconds_count{phase="get-areas",simulation="served"} 1`
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected %s in response", expected)
+		}
+	})
+
+	t.Run("forgets deleted simulation", func(t *testing.T) {
+		// Given
+		OnStep("deleted")(getTestSim(), sim.IterationData{Iteration: 1})
+		count := testutil.CollectAndCount(iteration)
+
+		// When
+		Forget("deleted")
+
+		// Then
+		if got := testutil.CollectAndCount(iteration); got != count-1 {
+			t.Errorf("Expected %d, got %d", count-1, got)
+		}
+	})
+}
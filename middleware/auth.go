@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// RoleContextKey defines key holding role of authenticated client in request
+// context
+const RoleContextKey = ContextKey("role")
+
+type Role string
+
+const (
+	// RoleReader can run queries and subscriptions
+	RoleReader Role = "reader"
+	// RoleOperator can also run mutations
+	RoleOperator Role = "operator"
+)
+
+var roleLevels = map[Role]int{
+	RoleReader:   1,
+	RoleOperator: 2,
+}
+
+// Keys maps API keys to roles
+type Keys map[string]Role
+
+// ReadKeys parses lines in "<role> <key>" format, empty lines and lines
+// starting with # are skipped
+func ReadKeys(r io.Reader) (Keys, error) {
+	keys := Keys{}
+	scanner := bufio.NewScanner(r)
+
+	for lineIndex := 1; scanner.Scan(); lineIndex++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Line %d must contain role and key", lineIndex)
+		}
+
+		role := Role(fields[0])
+		if _, ok := roleLevels[role]; !ok {
+			return nil, fmt.Errorf("Line %d has unknown role %s", lineIndex, role)
+		}
+		keys[fields[1]] = role
+	}
+
+	return keys, scanner.Err()
+}
+
+func LoadKeys(path string) (Keys, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadKeys(f)
+}
+
+// getKey reads key from Authorization or X-API-Key header. Browsers can't set
+// headers of WebSocket and EventSource requests, so apiKey query parameter is
+// accepted for them only, other requests would leak key to logs for nothing.
+func getKey(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if isStream(r) {
+		return r.URL.Query().Get("apiKey")
+	}
+
+	return ""
+}
+
+func isStream(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r) ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// getRole compares given key with every known one in constant time, so
+// response time doesn't tell how much of the key is right
+func (keys Keys) getRole(key string) (Role, bool) {
+	var (
+		found bool
+		role  Role
+	)
+	for knownKey, knownRole := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(knownKey)) == 1 {
+			found = true
+			role = knownRole
+		}
+	}
+
+	return role, found && key != ""
+}
+
+// GetClientID identifies client by API key checked by WithAuth, or by address
+// otherwise, so unchecked keys can't be rotated to get around limits
+func GetClientID(r *http.Request) string {
+	if _, ok := r.Context().Value(RoleContextKey).(Role); ok {
+		return "key:" + getKey(r)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// WithAuth rejects requests without valid API key and passes role of the key
+// in request context
+func WithAuth(keys Keys, next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			role, ok := keys.getRole(getKey(r))
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Invalid or missing API key", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), RoleContextKey, role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		},
+	)
+}
+
+// Authorize returns error if client's role is lower than required one.
+// Contexts without role come from servers running without authentication.
+func Authorize(ctx context.Context, required Role) error {
+	role, ok := ctx.Value(RoleContextKey).(Role)
+	if !ok {
+		return nil
+	}
+
+	if roleLevels[role] < roleLevels[required] {
+		return fmt.Errorf("Role %s is required, got %s", required, role)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuth(t *testing.T) {
+	keys, err := ReadKeys(strings.NewReader(`
+		# Lab dashboards
+		reader reader-key
+		operator operator-key
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var role Role
+	handler := WithAuth(keys, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			role, _ = r.Context().Value(RoleContextKey).(Role)
+		},
+	))
+
+	t.Run("rejects request without key", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+
+		// When
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/api", nil))
+
+		// Then
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("Expected %d, got %d", http.StatusUnauthorized, recorder.Code)
+		}
+	})
+
+	t.Run("passes role of bearer token", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/api", nil)
+		r.Header.Set("Authorization", "Bearer operator-key")
+
+		// When
+		handler.ServeHTTP(recorder, r)
+
+		// Then
+		if role != RoleOperator {
+			t.Errorf("Expected %s, got %s", RoleOperator, role)
+		}
+	})
+
+	t.Run("accepts key in query of event stream", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/events?apiKey=reader-key", nil)
+		r.Header.Set("Accept", "text/event-stream")
+
+		// When
+		handler.ServeHTTP(recorder, r)
+
+		// Then
+		if role != RoleReader {
+			t.Errorf("Expected %s, got %s", RoleReader, role)
+		}
+	})
+
+	t.Run("accepts key in query of websocket upgrade", func(t *testing.T) {
+		// Given
+		role = ""
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/api?apiKey=operator-key", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+
+		// When
+		handler.ServeHTTP(recorder, r)
+
+		// Then
+		if role != RoleOperator {
+			t.Errorf("Expected %s, got %s", RoleOperator, role)
+		}
+	})
+
+	t.Run("rejects key in query of other requests", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+
+		// When
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/api?apiKey=operator-key", nil))
+
+		// Then
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("Expected %d, got %d", http.StatusUnauthorized, recorder.Code)
+		}
+	})
+
+	t.Run("rejects key differing in last character", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/api", nil)
+		r.Header.Set("X-API-Key", "reader-kez")
+
+		// When
+		handler.ServeHTTP(recorder, r)
+
+		// Then
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("Expected %d, got %d", http.StatusUnauthorized, recorder.Code)
+		}
+	})
+
+	t.Run("rejects unknown role", func(t *testing.T) {
+		// When
+		_, err := ReadKeys(strings.NewReader("admin key"))
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	reader := context.WithValue(context.TODO(), RoleContextKey, RoleReader)
+	operator := context.WithValue(context.TODO(), RoleContextKey, RoleOperator)
+
+	if Authorize(reader, RoleOperator) == nil {
+		t.Error("Expected reader not to be allowed to run operator fields")
+	}
+	if err := Authorize(operator, RoleOperator); err != nil {
+		t.Error(err)
+	}
+	if err := Authorize(context.TODO(), RoleOperator); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetClientID(t *testing.T) {
+	t.Run("uses key checked by auth", func(t *testing.T) {
+		// Given
+		r := httptest.NewRequest("POST", "/api", nil)
+		r.Header.Set("X-API-Key", "reader-key")
+		r = r.WithContext(context.WithValue(r.Context(), RoleContextKey, RoleReader))
+
+		// When
+		id := GetClientID(r)
+
+		// Then
+		if id != "key:reader-key" {
+			t.Errorf("Expected %s, got %s", "key:reader-key", id)
+		}
+	})
+
+	t.Run("ignores key without auth", func(t *testing.T) {
+		// Given
+		r := httptest.NewRequest("POST", "/api", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.Header.Set("X-API-Key", "random-key")
+
+		// When
+		id := GetClientID(r)
+
+		// Then
+		if id != "ip:10.0.0.1" {
+			t.Errorf("Expected %s, got %s", "ip:10.0.0.1", id)
+		}
+	})
+}
@@ -18,8 +18,13 @@ func (handler CorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			"Accept",
 			"Authorization",
 			"Content-Type",
+			"X-API-Key",
+		},
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodOptions,
 		},
-		AllowedMethods: []string{http.MethodPost, http.MethodOptions},
 		AllowedOrigins: handler.allowedOrigins,
 	})
 	c.Handler(handler.next).ServeHTTP(w, r)
@@ -34,3 +39,22 @@ func WithCors(
 		next:           next,
 	}
 }
+
+// CheckOrigin accepts WebSocket upgrade requests from allowed origins only,
+// since browsers do not apply CORS to them
+func CheckOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		for _, allowedOrigin := range allowedOrigins {
+			if allowedOrigin == "*" || allowedOrigin == origin {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,231 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/dominik-zeglen/aquarium/metrics"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// DefaultID is used when query does not specify simulation
+const DefaultID = "default"
+
+type Simulation struct {
+	ID   string
+	Sim  *sim.Sim
+	Data *sim.IterationData
+
+	lock      sync.Mutex
+	iteration int
+	running   bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// onStep caches state that can be read without waiting for sim lock
+func (s *Simulation) onStep(_ *sim.Sim, data sim.IterationData) {
+	s.lock.Lock()
+	s.iteration = data.Iteration
+	s.lock.Unlock()
+}
+
+// GetConfig includes parameters changed by sim.Tune
+func (s *Simulation) GetConfig() sim.SimConfig {
+	return s.Sim.GetSnapshot().GetConfig()
+}
+
+func (s *Simulation) GetIteration() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.iteration
+}
+
+func (s *Simulation) IsRunning() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.running
+}
+
+func (s *Simulation) Start() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	if s.Sim.GetSnapshot().GetCellCount() == 0 {
+		return fmt.Errorf("Simulation %s has died out", s.ID)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.running = true
+	s.cancel = cancel
+	s.done = done
+
+	go func() {
+		defer close(done)
+		s.Sim.RunLoop(ctx, s.Data)
+
+		s.lock.Lock()
+		s.running = false
+		s.lock.Unlock()
+	}()
+
+	return nil
+}
+
+// Load replaces state of stopped simulation with checkpoint
+func (s *Simulation) Load(r io.Reader) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.running {
+		return fmt.Errorf("Simulation %s must be stopped before loading checkpoint", s.ID)
+	}
+
+	if err := s.Sim.ReadCheckpoint(r); err != nil {
+		return err
+	}
+	snapshot := s.Sim.GetSnapshot()
+	s.iteration = snapshot.GetIteration()
+	*s.Data = snapshot.GetData()
+
+	return nil
+}
+
+// Stop waits until current step is finished
+func (s *Simulation) Stop() {
+	s.lock.Lock()
+	cancel := s.cancel
+	done := s.done
+	s.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+type Registry struct {
+	defaults    sim.SimConfig
+	lock        sync.RWMutex
+	simulations map[string]*Simulation
+}
+
+// New creates registry, defaults are used as a base for simulations created
+// with partial config
+func New(defaults sim.SimConfig) *Registry {
+	return &Registry{
+		defaults:    defaults,
+		simulations: map[string]*Simulation{},
+	}
+}
+
+func (r *Registry) GetDefaultConfig() sim.SimConfig {
+	return r.defaults
+}
+
+func (r *Registry) Create(id string, config sim.SimConfig) (*Simulation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("Simulation ID cannot be empty")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.simulations[id]; ok {
+		return nil, fmt.Errorf("Simulation %s already exists", id)
+	}
+
+	s := &sim.Sim{}
+	s.Create(config)
+	simulation := &Simulation{
+		ID:   id,
+		Sim:  s,
+		Data: &sim.IterationData{},
+	}
+	s.OnStep(simulation.onStep)
+	s.OnStep(metrics.OnStep(id))
+	r.simulations[id] = simulation
+
+	return simulation, nil
+}
+
+func (r *Registry) Get(id string) (*Simulation, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	simulation, ok := r.simulations[id]
+	if !ok {
+		return nil, fmt.Errorf("Simulation %s not found", id)
+	}
+
+	return simulation, nil
+}
+
+// List returns simulations sorted by ID
+func (r *Registry) List() []*Simulation {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	simulations := make([]*Simulation, 0, len(r.simulations))
+	for _, simulation := range r.simulations {
+		simulations = append(simulations, simulation)
+	}
+	sort.Slice(simulations, func(i, j int) bool {
+		return simulations[i].ID < simulations[j].ID
+	})
+
+	return simulations
+}
+
+func (r *Registry) Start(id string) (*Simulation, error) {
+	simulation, err := r.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return simulation, simulation.Start()
+}
+
+func (r *Registry) Stop(id string) (*Simulation, error) {
+	simulation, err := r.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	simulation.Stop()
+
+	return simulation, nil
+}
+
+func (r *Registry) Delete(id string) error {
+	r.lock.Lock()
+	simulation, ok := r.simulations[id]
+	delete(r.simulations, id)
+	r.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("Simulation %s not found", id)
+	}
+	simulation.Stop()
+	metrics.Forget(id)
+
+	return nil
+}
+
+func (r *Registry) StopAll() {
+	for _, simulation := range r.List() {
+		simulation.Stop()
+	}
+}
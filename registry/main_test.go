@@ -0,0 +1,139 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+var config = sim.SimConfig{
+	EnvDivisions:       4,
+	MaxCellsInOrganism: 25,
+	MaxOrganisms:       1e3,
+	StartCells:         10,
+	WarmupIterations:   1e6,
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("creates unique simulations", func(t *testing.T) {
+		// Given
+		r := New(config)
+
+		// When
+		_, errA := r.Create("a", config)
+		_, errB := r.Create("b", config)
+		_, errDuplicate := r.Create("a", config)
+
+		// Then
+		if errA != nil || errB != nil {
+			t.Fatal(errA, errB)
+		}
+		if errDuplicate == nil {
+			t.Error("Expected error when creating duplicate")
+		}
+
+		simulations := r.List()
+		if len(simulations) != 2 || simulations[0].ID != "a" {
+			t.Errorf("Expected [a b], got %d simulations", len(simulations))
+		}
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		// Given
+		r := New(config)
+		invalid := config
+		invalid.StartCells = -1
+
+		// When
+		_, err := r.Create("a", invalid)
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+		if _, err := r.Get("a"); err == nil {
+			t.Error("Simulation should not be created")
+		}
+	})
+
+	t.Run("starts and stops simulation", func(t *testing.T) {
+		// Given
+		r := New(config)
+		r.Create("a", config)
+
+		// When
+		simulation, err := r.Start("a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+		r.Stop("a")
+
+		// Then
+		if simulation.IsRunning() {
+			t.Error("Simulation should not be running")
+		}
+
+		simulation.Sim.Lock()
+		iteration := simulation.Sim.GetIteration()
+		simulation.Sim.Unlock()
+		if iteration == 0 {
+			t.Error("Simulation did not run")
+		}
+		if simulation.Data.Iteration != iteration {
+			t.Errorf("Expected %d, got %d", iteration, simulation.Data.Iteration)
+		}
+	})
+
+	t.Run("deletes running simulation", func(t *testing.T) {
+		// Given
+		r := New(config)
+		r.Create("a", config)
+		simulation, _ := r.Start("a")
+
+		// When
+		err := r.Delete("a")
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if simulation.IsRunning() {
+			t.Error("Simulation should not be running")
+		}
+		if _, err := r.Get("a"); err == nil {
+			t.Error("Simulation should be removed")
+		}
+	})
+
+	t.Run("loads checkpoint into stopped simulation", func(t *testing.T) {
+		// Given
+		r := New(config)
+		source, _ := r.Create("source", config)
+		for it := 0; it < 5; it++ {
+			source.Sim.RunStep(context.TODO())
+		}
+		buf := bytes.Buffer{}
+		if err := source.Sim.WriteCheckpoint(&buf); err != nil {
+			t.Fatal(err)
+		}
+		simulation, _ := r.Create("a", config)
+
+		// When
+		err := simulation.Load(&buf)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if simulation.GetIteration() != 5 {
+			t.Errorf("Expected %d, got %d", 5, simulation.GetIteration())
+		}
+		if simulation.Data.Iteration != 5 {
+			t.Errorf("Expected %d, got %d", 5, simulation.Data.Iteration)
+		}
+	})
+}
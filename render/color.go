@@ -0,0 +1,103 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+var (
+	alive        = color.RGBA{230, 230, 230, 255}
+	darkness     = color.RGBA{8, 10, 24, 255}
+	dead         = color.RGBA{90, 90, 90, 255}
+	light        = color.RGBA{40, 70, 110, 255}
+	old          = color.RGBA{150, 20, 20, 255}
+	toxicityHigh = color.RGBA{170, 30, 20, 255}
+	toxicityLow  = color.RGBA{15, 40, 20, 255}
+	young        = color.RGBA{255, 230, 90, 255}
+)
+
+var dietColors = map[sim.Diet]color.RGBA{
+	sim.Funghi:    {170, 90, 200, 255},
+	sim.Herbivore: {80, 190, 80, 255},
+}
+
+// mix returns colour between a and b, ratio is clamped to [0, 1]
+func mix(a color.RGBA, b color.RGBA, ratio float64) color.RGBA {
+	ratio = math.Max(0, math.Min(1, ratio))
+	channel := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*ratio)
+	}
+
+	return color.RGBA{
+		channel(a.R, b.R),
+		channel(a.G, b.G),
+		channel(a.B, b.B),
+		255,
+	}
+}
+
+// Species with more than one diet get average colour of them
+func getDietColor(diets []sim.Diet) color.RGBA {
+	if len(diets) == 0 {
+		return alive
+	}
+
+	c := dietColors[diets[0]]
+	for dietIndex, diet := range diets[1:] {
+		c = mix(c, dietColors[diet], 1/float64(dietIndex+2))
+	}
+
+	return c
+}
+
+// getSpeciesColor spreads hues of subsequent species using golden ratio, so
+// related species which emerge one after another are easy to tell apart
+func getSpeciesColor(id int) color.RGBA {
+	hue := math.Mod(float64(id)*0.618033988749895, 1) * 6
+	x := 1 - math.Abs(math.Mod(hue, 2)-1)
+
+	var r, g, b float64
+	switch int(hue) {
+	case 0:
+		r, g, b = 1, x, 0
+	case 1:
+		r, g, b = x, 1, 0
+	case 2:
+		r, g, b = 0, 1, x
+	case 3:
+		r, g, b = 0, x, 1
+	case 4:
+		r, g, b = x, 0, 1
+	default:
+		r, g, b = 1, 0, x
+	}
+
+	// Keep saturation and value below maximum, so colours are not too bright
+	return color.RGBA{
+		uint8(60 + r*170),
+		uint8(60 + g*170),
+		uint8(60 + b*170),
+		255,
+	}
+}
+
+// fillCircle visits only pixels of the circle's bounding box which are inside
+// the image, so circles much larger than the image are cheap to draw
+func fillCircle(img *image.RGBA, center image.Point, radius float64, c color.RGBA) {
+	r := int(math.Ceil(radius))
+	area := image.Rect(center.X-r, center.Y-r, center.X+r+1, center.Y+r+1).
+		Intersect(img.Bounds())
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			dx := float64(x - center.X)
+			dy := float64(y - center.Y)
+			if dx*dx+dy*dy <= radius*radius {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+)
+
+func parseFloat(query url.Values, name string) (float64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, got %s", name, value)
+	}
+
+	return f, nil
+}
+
+func parseInt(query url.Values, name string) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %s", name, value)
+	}
+
+	return i, nil
+}
+
+// ParseOptions reads options from x0, y0, x1, y1, width, height, scheme and
+// cells query parameters
+func ParseOptions(query url.Values) (Options, error) {
+	options := Options{
+		Scheme: Scheme(query.Get("scheme")),
+		Cells:  query.Get("cells") == "true",
+	}
+
+	coords := []*float64{
+		&options.Start.X,
+		&options.Start.Y,
+		&options.End.X,
+		&options.End.Y,
+	}
+	for coordIndex, name := range []string{"x0", "y0", "x1", "y1"} {
+		value, err := parseFloat(query, name)
+		if err != nil {
+			return options, err
+		}
+		*coords[coordIndex] = value
+	}
+
+	var err error
+	if options.Width, err = parseInt(query, "width"); err != nil {
+		return options, err
+	}
+	if options.Height, err = parseInt(query, "height"); err != nil {
+		return options, err
+	}
+
+	return options, nil
+}
+
+type handler struct {
+	registry *registry.Registry
+}
+
+// Handler renders the latest snapshot of simulation picked with simulation
+// query parameter to PNG
+func Handler(r *registry.Registry) http.Handler {
+	return handler{r}
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	id := query.Get("simulation")
+	if id == "" {
+		id = registry.DefaultID
+	}
+	simulation, err := h.registry.Get(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	options, err := ParseOptions(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	img, err := Render(simulation.Sim.GetSnapshot(), options)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	png.Encode(w, img)
+}
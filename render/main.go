@@ -0,0 +1,246 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/golang/geo/r2"
+)
+
+type Scheme string
+
+const (
+	SchemeAge      Scheme = "age"
+	SchemeDiet     Scheme = "diet"
+	SchemeSpecies  Scheme = "species"
+	SchemeToxicity Scheme = "toxicity"
+)
+
+var Schemes = []Scheme{SchemeAge, SchemeDiet, SchemeSpecies, SchemeToxicity}
+
+const (
+	DefaultWidth = 500
+	// Both dimensions of rendered image must not exceed this
+	MaxSize = 4096
+	// Cells are drawn separately only if they're at least this many pixels wide
+	minCellSize = 3
+	// Both dimensions of rendered area must be at least this many world units,
+	// so zooming in can't blow organisms up to huge circles
+	MinAreaSize = 1
+)
+
+type Options struct {
+	// Rendered area, whole world is rendered if End is zero
+	Start r2.Point
+	End   r2.Point
+	// Height is computed from area's aspect ratio if it's zero
+	Width  int
+	Height int
+	Scheme Scheme
+	// Draw cells instead of organisms when zoomed in enough
+	Cells bool
+}
+
+func (o Options) validateArea() error {
+	if o.End.X <= o.Start.X || o.End.Y <= o.Start.Y {
+		return fmt.Errorf("Area end must be below and right of its start")
+	}
+	if o.End.X-o.Start.X < MinAreaSize || o.End.Y-o.Start.Y < MinAreaSize {
+		return fmt.Errorf("Area must be at least %d units wide and high", MinAreaSize)
+	}
+
+	return nil
+}
+
+// Validate checks options which do not depend on rendered sim
+func (o Options) Validate() error {
+	if o.Width < 0 || o.Height < 0 || o.Width > MaxSize || o.Height > MaxSize {
+		return fmt.Errorf(
+			"Image size must be between 1 and %d, got %dx%d",
+			MaxSize,
+			o.Width,
+			o.Height,
+		)
+	}
+
+	if o.End.X != 0 || o.End.Y != 0 {
+		if err := o.validateArea(); err != nil {
+			return err
+		}
+	}
+
+	if o.Scheme == "" {
+		return nil
+	}
+	for _, scheme := range Schemes {
+		if scheme == o.Scheme {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown colour scheme %s", o.Scheme)
+}
+
+func (o Options) withDefaults(env sim.Environment) (Options, error) {
+	if err := o.Validate(); err != nil {
+		return o, err
+	}
+
+	if o.End.X == 0 && o.End.Y == 0 {
+		o.End = r2.Point{X: float64(env.GetWidth()), Y: float64(env.GetHeight())}
+	}
+	if err := o.validateArea(); err != nil {
+		return o, err
+	}
+
+	if o.Width == 0 {
+		o.Width = DefaultWidth
+	}
+	if o.Height == 0 {
+		o.Height = int(float64(o.Width) * (o.End.Y - o.Start.Y) / (o.End.X - o.Start.X))
+	}
+	if o.Height < 1 || o.Height > MaxSize {
+		return o, fmt.Errorf(
+			"Image size must be between 1 and %d, got %dx%d",
+			MaxSize,
+			o.Width,
+			o.Height,
+		)
+	}
+	if o.Scheme == "" {
+		o.Scheme = SchemeSpecies
+	}
+
+	return o, nil
+}
+
+type renderer struct {
+	img     *image.RGBA
+	options Options
+	scale   r2.Point
+	s       *sim.Snapshot
+	maxAge  int
+}
+
+func (r renderer) toImage(position r2.Point) image.Point {
+	return image.Point{
+		X: int((position.X - r.options.Start.X) * r.scale.X),
+		Y: int((position.Y - r.options.Start.Y) * r.scale.Y),
+	}
+}
+
+func (r renderer) drawBackground() {
+	env := r.s.GetEnvironment()
+	// Toxicity is red once it exceeds tolerance of the most resistant species
+	tolerance := r.s.GetData().Waste.MaxTolerance
+	if tolerance <= 0 {
+		tolerance = env.GetToxicity()
+	}
+
+	for y := 0; y < r.options.Height; y++ {
+		height := r.options.Start.Y + float64(y)/r.scale.Y
+
+		var c color.RGBA
+		if r.options.Scheme == SchemeToxicity {
+			c = mix(toxicityLow, toxicityHigh, env.GetToxicityOnHeight(height)/tolerance)
+		} else {
+			c = mix(darkness, light, env.GetLightOnHeight(height, r.s.GetIteration()))
+		}
+
+		draw.Draw(
+			r.img,
+			image.Rect(0, y, r.options.Width, y+1),
+			&image.Uniform{c},
+			image.Point{},
+			draw.Src,
+		)
+	}
+}
+
+func (r renderer) getColor(organism sim.Organism) color.RGBA {
+	if !organism.IsAlive() {
+		return dead
+	}
+
+	switch r.options.Scheme {
+	case SchemeAge:
+		age := r.s.GetIteration() - organism.GetBornAt()
+		return mix(young, old, float64(age)/float64(r.maxAge))
+	case SchemeDiet:
+		return getDietColor(organism.GetSpecies().GetDiets())
+	case SchemeToxicity:
+		return alive
+	}
+
+	return getSpeciesColor(organism.GetSpecies().GetID())
+}
+
+func (r renderer) drawOrganism(organism sim.Organism) {
+	c := r.getColor(organism)
+	// Cell positions are offsets in world units, connected cells are 1 apart
+	cellSize := r.scale.X
+
+	if r.options.Cells && cellSize >= minCellSize {
+		for _, cell := range organism.GetCells() {
+			cellColor := c
+			if !cell.IsAlive() {
+				cellColor = dead
+			}
+
+			position := organism.GetPosition().Add(cell.GetPosition())
+			center := r.toImage(position)
+			half := int(cellSize * .45)
+			draw.Draw(
+				r.img,
+				image.Rect(center.X-half, center.Y-half, center.X+half+1, center.Y+half+1),
+				&image.Uniform{cellColor},
+				image.Point{},
+				draw.Over,
+			)
+		}
+		return
+	}
+
+	radius := math.Sqrt(float64(len(organism.GetCells()))) * cellSize / 2
+	fillCircle(r.img, r.toImage(organism.GetPosition()), math.Max(radius, 1), c)
+}
+
+// Render draws organisms of the snapshot on top of light or toxicity gradient
+func Render(s *sim.Snapshot, options Options) (*image.RGBA, error) {
+	options, err := options.withDefaults(s.GetEnvironment())
+	if err != nil {
+		return nil, err
+	}
+
+	r := renderer{
+		img:     image.NewRGBA(image.Rect(0, 0, options.Width, options.Height)),
+		options: options,
+		scale: r2.Point{
+			X: float64(options.Width) / (options.End.X - options.Start.X),
+			Y: float64(options.Height) / (options.End.Y - options.Start.Y),
+		},
+		s:      s,
+		maxAge: 1,
+	}
+
+	// Organisms partially visible at area edges are drawn as well
+	marginSize := math.Sqrt(float64(s.GetConfig().MaxCellsInOrganism))
+	margin := r2.Point{X: marginSize, Y: marginSize}
+	organisms := s.GetOrganisms().GetArea(options.Start.Sub(margin), options.End.Add(margin))
+	for _, organism := range organisms {
+		if age := s.GetIteration() - organism.GetBornAt(); age > r.maxAge {
+			r.maxAge = age
+		}
+	}
+
+	r.drawBackground()
+	for _, organism := range organisms {
+		r.drawOrganism(organism)
+	}
+
+	return r.img, nil
+}
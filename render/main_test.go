@@ -0,0 +1,150 @@
+package render
+
+import (
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/registry"
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/golang/geo/r2"
+)
+
+func getTestSim() *sim.Sim {
+	s := &sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+	for it := 0; it < 10; it++ {
+		s.RunStep(context.TODO())
+	}
+
+	return s
+}
+
+func TestRender(t *testing.T) {
+	t.Run("renders whole world keeping aspect ratio", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		img, err := Render(s.GetSnapshot(), Options{Width: 200})
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Bounds().Dx() != 200 || img.Bounds().Dy() != 200 {
+			t.Errorf("Expected 200x200, got %s", img.Bounds().Size())
+		}
+	})
+
+	t.Run("draws organism cells when zoomed in", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		organism := s.GetSnapshot().GetOrganisms()[0]
+		offset := r2.Point{X: 5, Y: 5}
+
+		// When
+		img, err := Render(s.GetSnapshot(), Options{
+			Start:  organism.GetPosition().Sub(offset),
+			End:    organism.GetPosition().Add(offset),
+			Width:  100,
+			Scheme: SchemeDiet,
+			Cells:  true,
+		})
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		color := getDietColor(organism.GetSpecies().GetDiets())
+		for _, cell := range organism.GetCells() {
+			// Cells are 1 world unit apart, which is 10 pixels here
+			position := offset.Add(cell.GetPosition()).Mul(10)
+			if position.X < 0 || position.X >= 100 || position.Y < 0 || position.Y >= 100 {
+				continue
+			}
+			c := img.RGBAAt(int(position.X), int(position.Y))
+			if c != color && c != dead {
+				t.Errorf("Expected cell at %v, got %v", cell.GetPosition(), c)
+			}
+		}
+	})
+
+	t.Run("rejects unknown scheme", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		_, err := Render(s.GetSnapshot(), Options{Scheme: "mood"})
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+
+	t.Run("rejects area smaller than one unit", func(t *testing.T) {
+		// Given
+		options := Options{End: r2.Point{X: 0.01, Y: 0.01}}
+
+		// When
+		err := options.Validate()
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+
+	t.Run("clips circles larger than image", func(t *testing.T) {
+		// Given
+		img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+		// When
+		fillCircle(img, image.Point{5, 5}, 1e9, alive)
+
+		// Then
+		if c := img.RGBAAt(0, 0); c != alive {
+			t.Errorf("Expected %v, got %v", alive, c)
+		}
+	})
+
+	t.Run("serves png", func(t *testing.T) {
+		// Given
+		r := registry.New(sim.SimConfig{})
+		r.Create(registry.DefaultID, sim.SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			StartCells:         10,
+		})
+		recorder := httptest.NewRecorder()
+
+		// When
+		Handler(r).ServeHTTP(
+			recorder,
+			httptest.NewRequest("GET", "/render?width=64&scheme=toxicity", nil),
+		)
+
+		// Then
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body)
+		}
+		img, err := png.Decode(recorder.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Bounds().Dx() != 64 {
+			t.Errorf("Expected %d, got %d", 64, img.Bounds().Dx())
+		}
+	})
+}
@@ -23,10 +23,10 @@ func getVecFromAngle(angle float64) r2.Point {
 	}
 }
 
-func getRandomAngle() float64 {
-	return rand.Float64() * 2 * math.Pi
+func getRandomAngle(rng *rand.Rand) float64 {
+	return rng.Float64() * 2 * math.Pi
 }
 
-func getRandomVec() r2.Point {
-	return getVecFromAngle(getRandomAngle())
+func getRandomVec(rng *rand.Rand) r2.Point {
+	return getVecFromAngle(getRandomAngle(rng))
 }
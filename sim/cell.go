@@ -32,7 +32,7 @@ func (c Cell) GetFood(
 		food += int(
 			float64(
 				c.cellType.Herbivore,
-			) * e.getLightOnHeight(
+			) * e.GetLightOnHeight(
 				c.position.Y+organismHeight,
 				iteration,
 			) * 3,
@@ -41,7 +41,7 @@ func (c Cell) GetFood(
 	if c.cellType.Funghi > 0 {
 		food += int(
 			c.cellType.getProcessedWaste(
-				e.getToxicityOnHeight(c.position.Y + organismHeight),
+				e.GetToxicityOnHeight(c.position.Y + organismHeight),
 			),
 		)
 	}
@@ -96,14 +96,18 @@ func (c Cell) canProcreate(iteration int) bool {
 		c.alive
 }
 
-func (c *Cell) shouldProcreate(iteration int) bool {
-	return c.canProcreate(iteration) && rand.Float32() > .1
+func (c *Cell) shouldProcreate(rng *rand.Rand, iteration int) bool {
+	return c.canProcreate(iteration) && rng.Float32() > .1
 }
 
-func (c *Cell) procreate(iteration int, produces []*CellType) Cell {
+func (c *Cell) procreate(
+	rng *rand.Rand,
+	iteration int,
+	produces []*CellType,
+) Cell {
 	food := c.cellType.GetMaxSatiation() / 2
 
-	produceIndex := rand.Intn(len(produces))
+	produceIndex := rng.Intn(len(produces))
 	ct := produces[produceIndex]
 
 	descendant := Cell{
@@ -111,7 +115,7 @@ func (c *Cell) procreate(iteration int, produces []*CellType) Cell {
 		bornAt:       iteration,
 		alive:        true,
 		cellType:     ct,
-		hp:           ct.getMaxHP(),
+		hp:           ct.GetMaxHP(),
 		procreatedAt: iteration,
 	}
 
@@ -126,30 +130,45 @@ func (c Cell) getAge(iteration int) int8 {
 	return int8(iteration - c.bornAt)
 }
 
-func (c Cell) shouldDie(
+// getDeathCause returns empty cause if cell should stay alive
+func (c Cell) getDeathCause(
 	env Environment,
 	iteration int,
 	organismPosition r2.Point,
-) bool {
+) DeathCause {
 	if !c.alive {
-		return false
+		return ""
 	}
 
 	age := c.getAge(iteration)
 	isStarving := c.satiation <= 0
 	isPastLifetime := c.cellType.GetTimeToDie() < age
-	isEnvironmentTooToxic := env.getToxicityOnHeight(
+	isEnvironmentTooToxic := env.GetToxicityOnHeight(
 		c.position.Y+organismPosition.Y,
 	) > c.cellType.GetWasteTolerance()
 
-	mustDie := isPastLifetime ||
-		isEnvironmentTooToxic ||
-		c.hp == 0 ||
-		isOutOfBounds(c.position.Add(organismPosition), env)
+	switch {
+	case isPastLifetime:
+		return DeathCauseLifetime
+	case isEnvironmentTooToxic:
+		return DeathCauseToxicity
+	case c.hp == 0:
+		return DeathCauseInjury
+	case isOutOfBounds(c.position.Add(organismPosition), env):
+		return DeathCauseOutOfBounds
+	case isStarving && age > 0:
+		return DeathCauseStarvation
+	}
 
-	dies := mustDie || (isStarving && age > 0)
+	return ""
+}
 
-	return dies
+func (c Cell) shouldDie(
+	env Environment,
+	iteration int,
+	organismPosition r2.Point,
+) bool {
+	return c.getDeathCause(env, iteration, organismPosition) != ""
 }
 
 func (c *Cell) die(iteration int) {
@@ -173,6 +192,12 @@ func (c Cell) IsAlive() bool {
 func (c Cell) GetHP() int {
 	return c.hp
 }
+func (c Cell) GetDiedAt() int {
+	return c.diedAt
+}
+func (c Cell) GetProcreatedAt() int {
+	return c.procreatedAt
+}
 func (c Cell) GetBornAt() int {
 	return c.bornAt
 }
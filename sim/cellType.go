@@ -56,6 +56,34 @@ func (t CellType) CanConnect() bool {
 	return t.connects >= 10
 }
 
+func (t CellType) GetConnects() int8 {
+	return t.connects
+}
+
+func (t CellType) GetShape() string {
+	return t.shape
+}
+
+func (t CellType) GetPoints() int {
+	return t.points
+}
+
+func (t CellType) GetMembrane() int {
+	return t.membrane
+}
+
+func (t CellType) GetEnzymes() int {
+	return t.enzymes
+}
+
+func (t CellType) GetTransport() int {
+	return t.transport
+}
+
+func (t CellType) GetMaxCapacity() int {
+	return t.maxCapacity
+}
+
 func (t CellType) GetMobility() int {
 	return t.mobility * 5
 }
@@ -76,23 +104,23 @@ func (t CellType) GetTimeToDie() int8 {
 	return 40 + int8(t.timeToDie/5)
 }
 
-func (t CellType) getMaxHP() int {
+func (t CellType) GetMaxHP() int {
 	return t.GetSize() * 23
 }
 
-func (t CellType) getMass() int {
+func (t CellType) GetMass() int {
 	return t.GetSize() * 10
 }
 
-func (t CellType) getFoodValue() int {
+func (t CellType) GetFoodValue() int {
 	return t.GetSize() * 2
 }
 
-func (t CellType) getDefence() int {
+func (t CellType) GetDefence() int {
 	return t.membrane / 10
 }
 
-func (t CellType) getAttack() int {
+func (t CellType) GetAttack() int {
 	return (int(t.Carnivore)*2 + t.GetSize() + t.enzymes) / 3
 }
 
@@ -202,9 +230,9 @@ func (t CellType) getDietPoints() int {
 
 }
 
-func (t *CellType) mutateDiet() {
+func (t *CellType) mutateDiet(rng *rand.Rand) {
 	if len(t.diets) == 1 {
-		if rand.Float32() > .9 {
+		if rng.Float32() > .9 {
 			if t.hasDiet(Herbivore) {
 				t.Herbivore /= 2
 				t.Funghi = t.Herbivore
@@ -226,7 +254,7 @@ func (t *CellType) mutateDiet() {
 		}
 	} else {
 		diets := []Diet{Herbivore, Funghi}
-		diet := diets[rand.Intn(len(diets))]
+		diet := diets[rng.Intn(len(diets))]
 		if diet == Herbivore {
 			t.Herbivore += t.Funghi
 			t.Funghi = 0
@@ -247,21 +275,21 @@ func (t CellType) copy() CellType {
 	return ct
 }
 
-func (t CellType) mutate() CellType {
+func (t CellType) mutate(rng *rand.Rand) CellType {
 	ct := t.copy()
 	ct.points++
 
-	if rand.Float32() > .95 {
-		ct.mutateDiet()
+	if rng.Float32() > .95 {
+		ct.mutateDiet(rng)
 
-		mutationCount := (rand.Intn(10) + 10)
+		mutationCount := (rng.Intn(10) + 10)
 		for i := 0; i < mutationCount; i++ {
-			ct = ct.mutateOnce()
+			ct = ct.mutateOnce(rng)
 		}
 	} else {
 		do := true
-		for do || rand.Float32() > .5 {
-			ct = ct.mutateOnce()
+		for do || rng.Float32() > .5 {
+			ct = ct.mutateOnce(rng)
 			do = false
 		}
 	}
@@ -269,24 +297,24 @@ func (t CellType) mutate() CellType {
 	return ct
 }
 
-func (t CellType) mutateOnce() CellType {
+func (t CellType) mutateOnce(rng *rand.Rand) CellType {
 	n := t
 	do := true
 
 	for do || !n.validate() || n.getInvestedPoints() < n.points {
-		attr := rand.Float64()
+		attr := rng.Float64()
 		value := 1
-		if rand.Float32() > .9 {
+		if rng.Float32() > .9 {
 			value = -1
 		}
 
 		for attr < .21 && n.getDietPoints() >= 100 && value > 0 {
-			attr = rand.Float64()
+			attr = rng.Float64()
 		}
 
 		if attr < .21 {
 			if len(t.diets) > 1 {
-				if rand.Float32() > .5 {
+				if rng.Float32() > .5 {
 					n.Herbivore += int8(value)
 				} else {
 					n.Funghi += int8(value)
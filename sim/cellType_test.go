@@ -13,7 +13,7 @@ func TestDietMutation(t *testing.T) {
 
 		// When
 		newType := ct.copy()
-		newType.mutateDiet()
+		newType.mutateDiet(getRand())
 
 		// Then
 		if &newType == &ct {
@@ -32,7 +32,7 @@ func TestDietMutation(t *testing.T) {
 
 		// When
 		newType := ct.copy()
-		newType.mutateDiet()
+		newType.mutateDiet(getRand())
 
 		// Then
 		if len(newType.diets) != 1 {
@@ -106,7 +106,7 @@ func TestProcreation(t *testing.T) {
 	}
 
 	// When
-	child := c.procreate(10, []*CellType{&ct})
+	child := c.procreate(getRand(), 10, []*CellType{&ct})
 
 	// Then
 	if !child.alive {
@@ -0,0 +1,437 @@
+package sim
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/golang/geo/r2"
+)
+
+// countingSource remembers its seed and how many numbers were drawn since, so
+// random generator can be restored by drawing them again from freshly seeded
+// source. Sim reseeds it every step, so only draws of one step are repeated.
+type countingSource struct {
+	source rand.Source64
+	seed   int64
+	drawn  uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{
+		source: rand.NewSource(seed).(rand.Source64),
+		seed:   seed,
+	}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.drawn++
+	return s.source.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.drawn++
+	return s.source.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.drawn = 0
+	s.seed = seed
+	s.source.Seed(seed)
+}
+
+func (s *countingSource) skip(count uint64) {
+	for ; s.drawn < count; s.drawn++ {
+		s.source.Int63()
+	}
+}
+
+// Bump it whenever checkpoint structure changes
+const checkpointVersion = 3
+
+// Checkpoint structs mirror sim state with exported fields, so they can be
+// encoded with gob
+type checkpointCellType struct {
+	ID             int
+	Shape          string
+	Diets          []Diet
+	Points         int
+	Size           int
+	Membrane       int
+	Enzymes        int
+	Herbivore      int8
+	Carnivore      int8
+	Funghi         int8
+	TimeToDie      int
+	WasteTolerance int
+	MaxSatiation   int
+	Consumption    int
+	Transport      int
+	MaxCapacity    int
+	Connects       int8
+	ProcreationCd  int
+	Mobility       int
+}
+
+type checkpointSpecies struct {
+	ID        int
+	EmergedAt int
+	Extinct   bool
+	Count     int
+	Points    int
+	Types     []checkpointCellType
+	Produces  [][]int
+}
+
+type checkpointCell struct {
+	ID           int
+	Position     r2.Point
+	CellType     int
+	Alive        bool
+	HP           int
+	BornAt       int
+	DiedAt       int
+	ProcreatedAt int
+	Satiation    int
+	Capacity     int
+}
+
+type checkpointOrganism struct {
+	ID         int
+	Angle      float64
+	Position   r2.Point
+	Action     Action
+	Target     r2.Point
+	Cells      []checkpointCell
+	LastCellID int
+	SpeciesID  int
+	BornAt     int
+	DiedAt     int
+	DeathCause DeathCause
+}
+
+// checkpointData holds data of the last step without species, which are saved
+// once in checkpoint
+type checkpointData struct {
+	CellCount      int
+	AliveCellCount int
+	Waste          WasteData
+	Iteration      int
+	CanProcreate   bool
+	MinCd          int8
+	MaxCd          int8
+	MinHeight      float64
+	MaxHeight      float64
+	Stats          StatsData
+}
+
+type checkpoint struct {
+	Version  int
+	Config   SimConfig
+	Data     checkpointData
+	Toxicity float64
+	// Kept apart from config, because gob would drop pointer to zero rate
+	MutationRate   float64
+	Iteration      int
+	OrganismLastID int
+	Organisms      []checkpointOrganism
+	RandomDrawn    uint64
+	RandomSeed     int64
+	Species        []checkpointSpecies
+	SpeciesLastID  int
+}
+
+func fromData(d IterationData) checkpointData {
+	return checkpointData{
+		CellCount:      d.CellCount,
+		AliveCellCount: d.AliveCellCount,
+		Waste:          d.Waste,
+		Iteration:      d.Iteration,
+		CanProcreate:   d.Procreation.CanProcreate,
+		MinCd:          d.Procreation.MinCd,
+		MaxCd:          d.Procreation.MaxCd,
+		MinHeight:      d.Procreation.MinHeight,
+		MaxHeight:      d.Procreation.MaxHeight,
+		Stats:          d.Stats,
+	}
+}
+
+func (d checkpointData) restore(species SpeciesList) IterationData {
+	return IterationData{
+		CellCount:      d.CellCount,
+		AliveCellCount: d.AliveCellCount,
+		Waste:          d.Waste,
+		Iteration:      d.Iteration,
+		Procreation: ProcreationData{
+			CanProcreate: d.CanProcreate,
+			MinCd:        d.MinCd,
+			MaxCd:        d.MaxCd,
+			MinHeight:    d.MinHeight,
+			MaxHeight:    d.MaxHeight,
+			Species:      species,
+		},
+		Stats: d.Stats,
+	}
+}
+
+func fromCellType(t CellType) checkpointCellType {
+	return checkpointCellType{
+		ID:             t.ID,
+		Shape:          t.shape,
+		Diets:          t.diets,
+		Points:         t.points,
+		Size:           t.size,
+		Membrane:       t.membrane,
+		Enzymes:        t.enzymes,
+		Herbivore:      t.Herbivore,
+		Carnivore:      t.Carnivore,
+		Funghi:         t.Funghi,
+		TimeToDie:      t.timeToDie,
+		WasteTolerance: t.wasteTolerance,
+		MaxSatiation:   t.maxSatiation,
+		Consumption:    t.consumption,
+		Transport:      t.transport,
+		MaxCapacity:    t.maxCapacity,
+		Connects:       t.connects,
+		ProcreationCd:  t.procreationCd,
+		Mobility:       t.mobility,
+	}
+}
+
+func (t checkpointCellType) restore() CellType {
+	return CellType{
+		ID:             t.ID,
+		shape:          t.Shape,
+		diets:          t.Diets,
+		points:         t.Points,
+		size:           t.Size,
+		membrane:       t.Membrane,
+		enzymes:        t.Enzymes,
+		Herbivore:      t.Herbivore,
+		Carnivore:      t.Carnivore,
+		Funghi:         t.Funghi,
+		timeToDie:      t.TimeToDie,
+		wasteTolerance: t.WasteTolerance,
+		maxSatiation:   t.MaxSatiation,
+		consumption:    t.Consumption,
+		transport:      t.Transport,
+		maxCapacity:    t.MaxCapacity,
+		connects:       t.Connects,
+		procreationCd:  t.ProcreationCd,
+		mobility:       t.Mobility,
+	}
+}
+
+func fromSpecies(s Species) checkpointSpecies {
+	types := make([]checkpointCellType, len(s.types))
+	for typeIndex := range s.types {
+		types[typeIndex] = fromCellType(s.types[typeIndex])
+	}
+
+	return checkpointSpecies{
+		ID:        s.id,
+		EmergedAt: s.emergedAt,
+		Extinct:   s.extinct,
+		Count:     s.count,
+		Points:    s.points,
+		Types:     types,
+		Produces:  s.produces,
+	}
+}
+
+func (s checkpointSpecies) restore() Species {
+	types := make([]CellType, len(s.Types))
+	for typeIndex := range s.Types {
+		types[typeIndex] = s.Types[typeIndex].restore()
+	}
+
+	return Species{
+		id:        s.ID,
+		emergedAt: s.EmergedAt,
+		extinct:   s.Extinct,
+		count:     s.Count,
+		points:    s.Points,
+		types:     types,
+		produces:  s.Produces,
+	}
+}
+
+func fromOrganism(o Organism) checkpointOrganism {
+	cells := make([]checkpointCell, len(o.cells))
+	for cellIndex, cell := range o.cells {
+		cells[cellIndex] = checkpointCell{
+			ID:           cell.id,
+			Position:     cell.position,
+			CellType:     cell.cellType.ID,
+			Alive:        cell.alive,
+			HP:           cell.hp,
+			BornAt:       cell.bornAt,
+			DiedAt:       cell.diedAt,
+			ProcreatedAt: cell.procreatedAt,
+			Satiation:    cell.satiation,
+			Capacity:     cell.capacity,
+		}
+	}
+
+	return checkpointOrganism{
+		ID:         o.id,
+		Angle:      o.angle,
+		Position:   o.position,
+		Action:     o.action,
+		Target:     o.target,
+		Cells:      cells,
+		LastCellID: o.lastCellId,
+		SpeciesID:  o.speciesID,
+		BornAt:     o.bornAt,
+		DiedAt:     o.diedAt,
+		DeathCause: o.deathCause,
+	}
+}
+
+// restore links organism to its species and cells to species' cell types
+func (o checkpointOrganism) restore(species *Species) (Organism, error) {
+	cells := make(CellList, len(o.Cells))
+	for cellIndex, cell := range o.Cells {
+		var cellType *CellType
+		for typeIndex := range species.types {
+			if species.types[typeIndex].ID == cell.CellType {
+				cellType = &species.types[typeIndex]
+			}
+		}
+		if cellType == nil {
+			return Organism{}, fmt.Errorf(
+				"Cell type %d of organism %d not found in species %d",
+				cell.CellType,
+				o.ID,
+				species.id,
+			)
+		}
+
+		cells[cellIndex] = Cell{
+			id:           cell.ID,
+			position:     cell.Position,
+			cellType:     cellType,
+			alive:        cell.Alive,
+			hp:           cell.HP,
+			bornAt:       cell.BornAt,
+			diedAt:       cell.DiedAt,
+			procreatedAt: cell.ProcreatedAt,
+			satiation:    cell.Satiation,
+			capacity:     cell.Capacity,
+		}
+	}
+
+	return Organism{
+		id:         o.ID,
+		angle:      o.Angle,
+		position:   o.Position,
+		action:     o.Action,
+		target:     o.Target,
+		cells:      cells,
+		lastCellId: o.LastCellID,
+		speciesID:  o.SpeciesID,
+		species:    species,
+		bornAt:     o.BornAt,
+		diedAt:     o.DiedAt,
+		deathCause: o.DeathCause,
+	}, nil
+}
+
+// WriteCheckpoint encodes whole state of the sim, including position of random
+// generator, so restored sim continues exactly as this one would. It must be
+// called while holding sim lock or when sim is not running.
+func (s *Sim) WriteCheckpoint(w io.Writer) error {
+	c := checkpoint{
+		Version:        checkpointVersion,
+		Config:         s.config,
+		Data:           fromData(s.data),
+		Toxicity:       s.env.toxicity,
+		MutationRate:   s.mutationRate,
+		Iteration:      s.iteration,
+		OrganismLastID: s.organismLastID,
+		Organisms:      make([]checkpointOrganism, len(s.organisms)),
+		RandomDrawn:    s.source.drawn,
+		RandomSeed:     s.source.seed,
+		Species:        make([]checkpointSpecies, len(s.species)),
+		SpeciesLastID:  s.speciesLastID,
+	}
+	for organismIndex, organism := range s.organisms {
+		c.Organisms[organismIndex] = fromOrganism(organism)
+	}
+	for speciesIndex, species := range s.species {
+		c.Species[speciesIndex] = fromSpecies(species)
+	}
+
+	return gob.NewEncoder(w).Encode(c)
+}
+
+// ReadCheckpoint replaces state of the sim with one written by WriteCheckpoint,
+// so it can be used instead of Create. Observers are kept. It must be called
+// while holding sim lock or when sim is not running.
+func (s *Sim) ReadCheckpoint(r io.Reader) error {
+	c := checkpoint{}
+	if err := gob.NewDecoder(r).Decode(&c); err != nil {
+		return fmt.Errorf("Could not read checkpoint: %s", err)
+	}
+	if c.Version != checkpointVersion {
+		return fmt.Errorf(
+			"Could not read checkpoint: version %d is not supported, expected %d",
+			c.Version,
+			checkpointVersion,
+		)
+	}
+
+	species := make(SpeciesList, len(c.Species))
+	speciesMap := make(map[int]*Species, len(species))
+	for speciesIndex := range c.Species {
+		species[speciesIndex] = c.Species[speciesIndex].restore()
+		speciesMap[species[speciesIndex].id] = &species[speciesIndex]
+	}
+
+	organisms := make(OrganismList, len(c.Organisms))
+	for organismIndex, organism := range c.Organisms {
+		sp, ok := speciesMap[organism.SpeciesID]
+		if !ok {
+			return fmt.Errorf(
+				"Could not read checkpoint: species %d of organism %d not found",
+				organism.SpeciesID,
+				organism.ID,
+			)
+		}
+
+		var err error
+		if organisms[organismIndex], err = organism.restore(sp); err != nil {
+			return fmt.Errorf("Could not read checkpoint: %s", err)
+		}
+	}
+
+	source := newCountingSource(c.RandomSeed)
+	source.skip(c.RandomDrawn)
+
+	mutationRate := c.MutationRate
+	s.config = c.Config
+	s.config.MutationRate = &mutationRate
+	s.source = source
+	s.rng = rand.New(source)
+	s.iteration = c.Iteration
+	s.env = Environment{c.Toxicity, c.Config.EnvWidth, c.Config.EnvHeight}
+	s.organisms = organisms
+	s.organismLastID = c.OrganismLastID
+	s.species = species
+	s.speciesLastID = c.SpeciesLastID
+	s.maxCells = c.Config.MaxOrganisms
+	s.areaCount = c.Config.EnvDivisions
+	s.verbose = c.Config.Verbose
+	s.warmupIterations = c.Config.WarmupIterations
+	s.maxCellsInOrganism = c.Config.MaxCellsInOrganism
+	s.mutationRate = c.MutationRate
+	if s.commands == nil {
+		s.commands = make(chan queuedCommand, commandQueueSize)
+	}
+
+	s.data = c.Data.restore(s.species)
+	s.publish()
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package sim
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("restored sim continues like the original one", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		for it := 0; it < 20; it++ {
+			s.RunStep(context.TODO())
+		}
+		buf := bytes.Buffer{}
+		if err := s.WriteCheckpoint(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// When
+		restored := &Sim{}
+		err := restored.ReadCheckpoint(&buf)
+		for it := 0; it < 20; it++ {
+			s.RunStep(context.TODO())
+			restored.RunStep(context.TODO())
+		}
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.GetIteration() != s.GetIteration() {
+			t.Errorf("Expected %d, got %d", s.GetIteration(), restored.GetIteration())
+		}
+		if restored.GetCellCount() != s.GetCellCount() {
+			t.Fatalf("Expected %d, got %d", s.GetCellCount(), restored.GetCellCount())
+		}
+		if len(restored.GetSpecies()) != len(s.GetSpecies()) {
+			t.Errorf("Expected %d, got %d", len(s.GetSpecies()), len(restored.GetSpecies()))
+		}
+		for organismIndex, organism := range s.GetOrganisms() {
+			got := restored.GetOrganisms()[organismIndex]
+			if got.GetPosition() != organism.GetPosition() {
+				t.Errorf("Expected %v, got %v", organism.GetPosition(), got.GetPosition())
+			}
+			if len(got.GetCells()) != len(organism.GetCells()) {
+				t.Errorf("Expected %d, got %d", len(organism.GetCells()), len(got.GetCells()))
+			}
+		}
+	})
+
+	t.Run("replays only draws of the last step after long run", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		for it := 0; it < 1000; it++ {
+			s.RunStep(context.TODO())
+		}
+		buf := bytes.Buffer{}
+		if err := s.WriteCheckpoint(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// When
+		restored := &Sim{}
+		err := restored.ReadCheckpoint(&buf)
+		seed := restored.source.seed
+		s.RunStep(context.TODO())
+		restored.RunStep(context.TODO())
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seed != getStepSeed(1, 1000) {
+			t.Errorf("Expected %d, got %d", getStepSeed(1, 1000), seed)
+		}
+		if restored.GetCellCount() != s.GetCellCount() {
+			t.Errorf("Expected %d, got %d", s.GetCellCount(), restored.GetCellCount())
+		}
+	})
+
+	t.Run("keeps zero mutation rate", func(t *testing.T) {
+		// Given
+		mutationRate := 0.
+		s := &Sim{}
+		s.Create(SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			MutationRate:       &mutationRate,
+			Seed:               1,
+			StartCells:         10,
+		})
+		buf := bytes.Buffer{}
+		if err := s.WriteCheckpoint(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		// When
+		restored := &Sim{}
+		err := restored.ReadCheckpoint(&buf)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.mutationRate != 0 || *restored.GetConfig().MutationRate != 0 {
+			t.Errorf("Expected %g, got %g", 0., restored.mutationRate)
+		}
+	})
+
+	t.Run("rejects corrupted checkpoint", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		buf := bytes.Buffer{}
+		if err := s.WriteCheckpoint(&buf); err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()[:buf.Len()/2]
+
+		// When
+		restored := &Sim{}
+		err := restored.ReadCheckpoint(bytes.NewReader(data))
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
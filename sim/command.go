@@ -0,0 +1,79 @@
+package sim
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Command modifies sim between steps, it's called while holding sim lock
+type Command func(s *Sim) error
+
+// Commands sent while queue is full wait until RunLoop drains it
+const commandQueueSize = 64
+
+type commandResult struct {
+	snapshot *Snapshot
+	err      error
+}
+
+type queuedCommand struct {
+	command Command
+	done    chan commandResult
+}
+
+// Exec queues command and waits until it's applied by RunLoop, or applies it
+// immediately if loop is not running. Returned snapshot already contains
+// changes made by the command. Command that has been queued is applied even if
+// context is cancelled before it's done.
+func (s *Sim) Exec(ctx context.Context, command Command) (*Snapshot, error) {
+	done := make(chan commandResult, 1)
+
+	select {
+	case s.commands <- queuedCommand{command, done}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if atomic.LoadInt32(&s.looping) == 0 {
+		s.lock.Lock()
+		s.runCommands()
+		s.lock.Unlock()
+	}
+
+	select {
+	case result := <-done:
+		return result.snapshot, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runCommands applies received and all queued commands, then publishes
+// snapshot. It must be called while holding sim lock.
+func (s *Sim) runCommands(received ...queuedCommand) {
+	queued := received
+	for len(queued) < len(received)+cap(s.commands) {
+		select {
+		case command := <-s.commands:
+			queued = append(queued, command)
+			continue
+		default:
+		}
+		break
+	}
+
+	if len(queued) == 0 {
+		return
+	}
+
+	errs := make([]error, len(queued))
+	for commandIndex, command := range queued {
+		errs[commandIndex] = command.command(s)
+	}
+
+	s.publish()
+	snapshot := s.GetSnapshot()
+	for commandIndex, command := range queued {
+		command.done <- commandResult{snapshot, errs[commandIndex]}
+	}
+}
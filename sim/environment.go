@@ -15,12 +15,28 @@ func (e *Environment) changeToxicity(value float64) {
 	}
 }
 
-func (e Environment) getToxicityOnHeight(height float64) float64 {
+func (e Environment) GetToxicityOnHeight(height float64) float64 {
 	return e.toxicity / 2 * (height/float64(e.height) + 1)
 }
 
-func (e Environment) getLightOnHeight(height float64, iteration int) float64 {
-	hour := (iteration / 10) % 24
-	light := math.Abs(float64((hour - 12)))
+// GetHour returns hour of simulated day, every hour lasts 10 iterations
+func GetHour(iteration int) int {
+	return (iteration / 10) % 24
+}
+
+func (e Environment) GetLightOnHeight(height float64, iteration int) float64 {
+	light := math.Abs(float64((GetHour(iteration) - 12)))
 	return light*(1-height/float64(e.height))*.8 + .2
 }
+
+func (e Environment) GetToxicity() float64 {
+	return e.toxicity
+}
+
+func (e Environment) GetWidth() int {
+	return e.width
+}
+
+func (e Environment) GetHeight() int {
+	return e.height
+}
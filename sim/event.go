@@ -0,0 +1,108 @@
+package sim
+
+import "github.com/golang/geo/r2"
+
+type EventType string
+
+const (
+	// EventBirth is emitted for organisms split off from parent
+	EventBirth = EventType("birth")
+	// EventDeath is emitted when last cell of organism dies
+	EventDeath = EventType("death")
+	// EventMutation is emitted when organism mutates into new species
+	EventMutation = EventType("mutation")
+	// EventDecay is emitted when dead cells are removed from organism
+	EventDecay = EventType("decay")
+	// EventSpeciesEmerged is emitted when new species is added
+	EventSpeciesEmerged = EventType("speciesEmerged")
+	// EventSpeciesExtinct is emitted when last specimen of species is gone
+	EventSpeciesExtinct = EventType("speciesExtinct")
+	// EventEnvironmentChange is emitted when toxicity changes
+	EventEnvironmentChange = EventType("environmentChange")
+)
+
+type DeathCause string
+
+const (
+	// DeathCauseAge is set for organisms which outlived their maximum age
+	DeathCauseAge = DeathCause("age")
+	// DeathCauseInfancy is set for organisms which died in the first iterations
+	DeathCauseInfancy = DeathCause("infancy")
+	DeathCauseInjury  = DeathCause("injury")
+	// DeathCauseIntervention is set for organisms killed through API
+	DeathCauseIntervention = DeathCause("intervention")
+	// DeathCauseLifetime is set when cell outlived lifetime of its type
+	DeathCauseLifetime    = DeathCause("lifetime")
+	DeathCauseOutOfBounds = DeathCause("outOfBounds")
+	DeathCauseStarvation  = DeathCause("starvation")
+	DeathCauseToxicity    = DeathCause("toxicity")
+)
+
+// NoID replaces organism or species ID in events unrelated to them
+const NoID = -1
+
+type Event struct {
+	Type       EventType              `json:"type"`
+	Iteration  int                    `json:"it"`
+	OrganismID int                    `json:"organism"`
+	SpeciesID  int                    `json:"species"`
+	Position   r2.Point               `json:"position"`
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+}
+
+func (s *Sim) notify(event Event) {
+	for _, entry := range s.observers {
+		entry.observer.OnEvent(event)
+	}
+}
+
+func (s *Sim) emit(
+	eventType EventType,
+	organism Organism,
+	payload map[string]interface{},
+) {
+	if len(s.observers) == 0 {
+		return
+	}
+
+	s.notify(Event{
+		Type:       eventType,
+		Iteration:  s.iteration,
+		OrganismID: organism.id,
+		SpeciesID:  organism.speciesID,
+		Position:   organism.position,
+		Payload:    payload,
+	})
+}
+
+func (s *Sim) emitSpecies(
+	eventType EventType,
+	species Species,
+	payload map[string]interface{},
+) {
+	if len(s.observers) == 0 {
+		return
+	}
+
+	s.notify(Event{
+		Type:       eventType,
+		Iteration:  s.iteration,
+		OrganismID: NoID,
+		SpeciesID:  species.id,
+		Payload:    payload,
+	})
+}
+
+func (s *Sim) emitEnvironment(payload map[string]interface{}) {
+	if len(s.observers) == 0 {
+		return
+	}
+
+	s.notify(Event{
+		Type:       EventEnvironmentChange,
+		Iteration:  s.iteration,
+		OrganismID: NoID,
+		SpeciesID:  NoID,
+		Payload:    payload,
+	})
+}
@@ -0,0 +1,191 @@
+package sim
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/r2"
+)
+
+// Interventions are meant to be called from commands passed to Exec, so they
+// are applied between steps and published in the next snapshot
+
+func (s *Sim) getSpeciesIndex(id int) int {
+	for speciesIndex := range s.species {
+		if s.species[speciesIndex].id == id {
+			return speciesIndex
+		}
+	}
+
+	return -1
+}
+
+func (s *Sim) getRandomPosition(start r2.Point, end r2.Point) r2.Point {
+	return fitToBoundary(r2.Point{
+		X: start.X + (end.X-start.X)*s.rng.Float64(),
+		Y: start.Y + (end.Y-start.Y)*s.rng.Float64(),
+	}, s.env)
+}
+
+func (s *Sim) spawn(species *Species, position r2.Point) {
+	organism := newOrganism(s.rng, s.GetNewOrganismID(), species, position)
+	organism.bornAt = s.iteration
+	s.organisms = append(s.organisms, organism)
+
+	s.emit(EventBirth, organism, map[string]interface{}{
+		"parent": NoID,
+		"cells":  len(organism.cells),
+	})
+}
+
+// getSpawned refreshes species pointers and returns recently spawned organisms
+func (s *Sim) getSpawned(count int) OrganismList {
+	s.reindexSpecies()
+
+	return s.organisms[len(s.organisms)-count:]
+}
+
+// checkCount keeps spawned organisms within limit of alive ones, so single
+// intervention can't stall the next step
+func (s *Sim) checkCount(count int) error {
+	if count < 1 {
+		return fmt.Errorf("Count must be positive, got %d", count)
+	}
+
+	left := s.maxCells - s.organisms.GetAliveCount()
+	if count > left {
+		return fmt.Errorf(
+			"Count must not exceed %d organisms left below maxOrganisms, got %d",
+			left,
+			count,
+		)
+	}
+
+	return nil
+}
+
+// SpawnOrganism adds single-cell organism of existing species
+func (s *Sim) SpawnOrganism(speciesID int, position r2.Point) (Organism, error) {
+	speciesIndex := s.getSpeciesIndex(speciesID)
+	if speciesIndex == -1 {
+		return Organism{}, fmt.Errorf("Species %d does not exist", speciesID)
+	}
+
+	if err := s.checkCount(1); err != nil {
+		return Organism{}, err
+	}
+
+	s.spawn(&s.species[speciesIndex], fitToBoundary(position, s.env))
+	s.species[speciesIndex].count++
+
+	return s.getSpawned(1)[0], nil
+}
+
+// CloneSpecies copies species as a new one and spawns its organisms in area
+func (s *Sim) CloneSpecies(
+	speciesID int,
+	start r2.Point,
+	end r2.Point,
+	count int,
+) (Species, error) {
+	speciesIndex := s.getSpeciesIndex(speciesID)
+	if speciesIndex == -1 {
+		return Species{}, fmt.Errorf("Species %d does not exist", speciesID)
+	}
+	if err := s.checkCount(count); err != nil {
+		return Species{}, err
+	}
+
+	clone := s.addSpecies(s.species[speciesIndex])
+	for i := 0; i < count; i++ {
+		s.spawn(clone, s.getRandomPosition(start, end))
+	}
+	clone.count = count
+
+	return *s.getSpawned(count)[0].species, nil
+}
+
+// Reseed adds random population, same as the one sim starts with
+func (s *Sim) Reseed(count int) (OrganismList, error) {
+	if err := s.checkCount(count); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < count; i++ {
+		organism := getRandomOrganism(s.rng, s.GetNewOrganismID(), s.env, s.addSpecies)
+		organism.bornAt = s.iteration
+		s.organisms = append(s.organisms, organism)
+
+		s.emit(EventBirth, organism, map[string]interface{}{
+			"parent": NoID,
+			"cells":  len(organism.cells),
+		})
+	}
+
+	return s.getSpawned(count), nil
+}
+
+func (s *Sim) kill(organismIndex int) bool {
+	organism := &s.organisms[organismIndex]
+	if !organism.IsAlive() {
+		return false
+	}
+
+	organism.die(s.iteration)
+	organism.diedAt = s.iteration
+	organism.deathCause = DeathCauseIntervention
+
+	s.emit(EventDeath, *organism, map[string]interface{}{
+		"cause": DeathCauseIntervention,
+		"age":   s.iteration - organism.bornAt,
+	})
+
+	return true
+}
+
+func (s *Sim) KillOrganism(id int) (Organism, error) {
+	for organismIndex := range s.organisms {
+		if s.organisms[organismIndex].id == id {
+			if !s.kill(organismIndex) {
+				return Organism{}, fmt.Errorf("Organism %d is already dead", id)
+			}
+
+			return s.organisms[organismIndex], nil
+		}
+	}
+
+	return Organism{}, fmt.Errorf("Organism %d does not exist", id)
+}
+
+// KillArea kills every organism in area and returns them
+func (s *Sim) KillArea(start r2.Point, end r2.Point) OrganismList {
+	killed := OrganismList{}
+
+	for organismIndex, organism := range s.organisms {
+		position := organism.position
+		if position.X > start.X && position.X < end.X &&
+			position.Y > start.Y && position.Y < end.Y &&
+			s.kill(organismIndex) {
+			killed = append(killed, s.organisms[organismIndex])
+		}
+	}
+
+	return killed
+}
+
+func (s *Sim) AddToxicity(value float64) float64 {
+	toxicity := s.env.toxicity
+	s.env.changeToxicity(value)
+
+	if s.env.toxicity != toxicity {
+		s.emitEnvironment(map[string]interface{}{
+			"toxicity":         s.env.toxicity,
+			"previousToxicity": toxicity,
+		})
+	}
+
+	return s.env.toxicity
+}
+
+func (s *Sim) SetToxicity(value float64) float64 {
+	return s.AddToxicity(value - s.env.toxicity)
+}
@@ -0,0 +1,138 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r2"
+)
+
+func TestInterventions(t *testing.T) {
+	t.Run("spawns organism of existing species", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		speciesID := s.GetSpecies()[0].GetID()
+		position := r2.Point{X: 100, Y: 200}
+
+		// When
+		organism, err := s.SpawnOrganism(speciesID, position)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if organism.GetSpecies().GetID() != speciesID {
+			t.Errorf("Expected %d, got %d", speciesID, organism.GetSpecies().GetID())
+		}
+		if organism.GetPosition() != position {
+			t.Errorf("Expected %v, got %v", position, organism.GetPosition())
+		}
+		if s.GetCellCount() != 11 {
+			t.Errorf("Expected %d, got %d", 11, s.GetCellCount())
+		}
+	})
+
+	t.Run("kills organisms in area", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		deaths := 0
+		s.OnEvent(func(event Event) {
+			if event.Type == EventDeath &&
+				event.Payload["cause"] == DeathCauseIntervention {
+				deaths++
+			}
+		})
+
+		// When
+		killed := s.KillArea(r2.Point{X: 0, Y: 0}, r2.Point{X: 1e4, Y: 1e4})
+
+		// Then
+		if len(killed) != 10 {
+			t.Errorf("Expected %d, got %d", 10, len(killed))
+		}
+		if deaths != 10 {
+			t.Errorf("Expected %d, got %d", 10, deaths)
+		}
+		if s.GetAliveCount() != 0 {
+			t.Errorf("Expected %d, got %d", 0, s.GetAliveCount())
+		}
+	})
+
+	t.Run("clones species into area", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		original := s.GetSpecies()[0]
+		start := r2.Point{X: 100, Y: 100}
+		end := r2.Point{X: 200, Y: 200}
+
+		// When
+		clone, err := s.CloneSpecies(original.GetID(), start, end, 5)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clone.GetID() == original.GetID() {
+			t.Error("Expected clone to be new species")
+		}
+		organisms := s.GetOrganisms().GetSpecies(clone.GetID())
+		if len(organisms) != 5 {
+			t.Errorf("Expected %d, got %d", 5, len(organisms))
+		}
+		if len(organisms.GetArea(start, end)) != 5 {
+			t.Error("Expected organisms to be placed in area")
+		}
+	})
+
+	t.Run("rejects spawning above organism limit", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		_, err := s.Reseed(s.GetConfig().MaxOrganisms)
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+		if s.GetCellCount() != 10 {
+			t.Errorf("Expected %d, got %d", 10, s.GetCellCount())
+		}
+	})
+
+	t.Run("leaves species cleanup to the next step", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		s.addSpecies(s.GetSpecies()[0])
+		extinct := 0
+		s.OnEvent(func(event Event) {
+			if event.Type == EventSpeciesExtinct {
+				extinct++
+			}
+		})
+
+		// When
+		_, err := s.Reseed(3)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if extinct != 0 {
+			t.Errorf("Expected %d, got %d", 0, extinct)
+		}
+	})
+
+	t.Run("sets toxicity", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		s.SetToxicity(10)
+		toxicity := s.AddToxicity(-20)
+
+		// Then
+		if toxicity != 0 {
+			t.Errorf("Expected %f, got %f", 0., toxicity)
+		}
+	})
+}
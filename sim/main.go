@@ -1,5 +1,10 @@
 package sim
 
+import (
+	"fmt"
+	"time"
+)
+
 type AddSpecies func(species Species) *Species
 
 type WasteData struct {
@@ -17,10 +22,47 @@ type ProcreationData struct {
 	Species      []Species `json:"species"`
 }
 
+// StepDurations holds time spent in phases of the last step
+type StepDurations struct {
+	Step           time.Duration
+	GetAreas       time.Duration
+	Sim            time.Duration
+	CleanupSpecies time.Duration
+}
+
+// StatsData summarises state of the sim at the end of the step
+type StatsData struct {
+	Organisms           int     `json:"organisms"`
+	Species             int     `json:"species"`
+	Toxicity            float64 `json:"toxicity"`
+	HighestLevel        int     `json:"highestLevel"`
+	AvgLevel            int     `json:"avgLevel"`
+	HighestConnectivity int     `json:"highestConnectivity"`
+	AvgConnectivity     int     `json:"avgConnectivity"`
+	Connecting          int     `json:"connecting"`
+}
+
 type IterationData struct {
 	CellCount      int             `json:"cellCount"`
 	AliveCellCount int             `json:"aliveCellCount"`
 	Waste          WasteData       `json:"waste"`
 	Iteration      int             `json:"iteration"`
 	Procreation    ProcreationData `json:"procreation"`
+	Stats          StatsData       `json:"stats"`
+}
+
+// String returns line printed after each step in verbose mode
+func (d IterationData) String() string {
+	return fmt.Sprintf(
+		"It: %6d, o: %5d, w: %.4f sp: %4d, HLvl: %3d, AvgLvl: %3d, HCn: %2d, AvgCn: %2d, CCn: %2d",
+		d.Iteration,
+		d.Stats.Organisms,
+		d.Stats.Toxicity,
+		d.Stats.Species,
+		d.Stats.HighestLevel,
+		d.Stats.AvgLevel,
+		d.Stats.HighestConnectivity,
+		d.Stats.AvgConnectivity,
+		d.Stats.Connecting,
+	)
 }
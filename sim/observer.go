@@ -0,0 +1,125 @@
+package sim
+
+import "sync/atomic"
+
+// Observer is notified about finished steps and lifecycle events. Observers
+// registered with Observe are called synchronously, while holding sim lock.
+type Observer interface {
+	OnStep(s *Sim, data IterationData)
+	OnEvent(event Event)
+}
+
+// StepHandler is called after each step while holding sim lock
+type StepHandler func(s *Sim, data IterationData)
+
+func (h StepHandler) OnStep(s *Sim, data IterationData) {
+	h(s, data)
+}
+func (h StepHandler) OnEvent(event Event) {}
+
+// EventHandler is called while holding sim lock
+type EventHandler func(event Event)
+
+func (h EventHandler) OnStep(s *Sim, data IterationData) {}
+func (h EventHandler) OnEvent(event Event) {
+	h(event)
+}
+
+type observerEntry struct {
+	id       int
+	observer Observer
+}
+
+// Observe registers observer and returns function removing it. Both must be
+// called while holding sim lock or before sim is started.
+func (s *Sim) Observe(observer Observer) func() {
+	s.observerLastID++
+	id := s.observerLastID
+	s.observers = append(s.observers, observerEntry{id, observer})
+
+	// Observers may remove themselves while being notified, so list being
+	// iterated is replaced instead of modified
+	return func() {
+		observers := make([]observerEntry, 0, len(s.observers))
+		for _, entry := range s.observers {
+			if entry.id != id {
+				observers = append(observers, entry)
+			}
+		}
+		s.observers = observers
+	}
+}
+
+func (s *Sim) OnStep(handler StepHandler) {
+	s.Observe(handler)
+}
+
+func (s *Sim) OnEvent(handler EventHandler) {
+	s.Observe(handler)
+}
+
+// Notification holds either finished step data or event
+type Notification struct {
+	Data  *IterationData
+	Event *Event
+}
+
+// Channel sends notifications to buffered channel. Notifications are dropped
+// when channel is full, so slow readers never stall RunStep.
+type Channel struct {
+	C       chan Notification
+	dropped uint64
+}
+
+func NewChannel(size int) *Channel {
+	return &Channel{
+		C: make(chan Notification, size),
+	}
+}
+
+func (c *Channel) send(notification Notification) {
+	select {
+	case c.C <- notification:
+	default:
+		atomic.AddUint64(&c.dropped, 1)
+	}
+}
+
+func (c *Channel) OnStep(s *Sim, data IterationData) {
+	// Species list is reused by sim in the next step
+	data.Procreation.Species = append(SpeciesList{}, data.Procreation.Species...)
+	c.send(Notification{Data: &data})
+}
+
+func (c *Channel) OnEvent(event Event) {
+	c.send(Notification{Event: &event})
+}
+
+// GetDropped returns number of notifications lost because of full buffer
+func (c *Channel) GetDropped() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// Close must be called after channel is removed from sim
+func (c *Channel) Close() {
+	close(c.C)
+}
+
+// Async calls observer on its own goroutine, fed by buffered channel of given
+// size. OnStep receives nil sim, because it's called without sim lock.
+// Closing returned channel stops the goroutine.
+func Async(observer Observer, size int) *Channel {
+	c := NewChannel(size)
+
+	go func() {
+		for notification := range c.C {
+			if notification.Data != nil {
+				observer.OnStep(nil, *notification.Data)
+			} else {
+				observer.OnEvent(*notification.Event)
+			}
+		}
+	}()
+
+	return c
+}
@@ -0,0 +1,133 @@
+package sim
+
+import (
+	"context"
+	"testing"
+)
+
+func getTestSim() *Sim {
+	s := &Sim{}
+	s.Create(SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+
+	return s
+}
+
+func TestObserver(t *testing.T) {
+	t.Run("notifies about steps and events", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		steps := 0
+		events := map[EventType]int{}
+		s.OnStep(func(_ *Sim, data IterationData) {
+			steps++
+		})
+		s.OnEvent(func(event Event) {
+			events[event.Type]++
+		})
+
+		// When
+		for it := 0; it < 100; it++ {
+			s.RunStep(context.TODO())
+		}
+
+		// Then
+		if steps != 100 {
+			t.Errorf("Expected %d, got %d", 100, steps)
+		}
+		if events[EventEnvironmentChange] == 0 {
+			t.Error("Expected environment change events")
+		}
+	})
+
+	t.Run("stops notifying removed observer", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		steps := 0
+		remove := s.Observe(StepHandler(func(_ *Sim, data IterationData) {
+			steps++
+		}))
+
+		// When
+		s.RunStep(context.TODO())
+		remove()
+		s.RunStep(context.TODO())
+
+		// Then
+		if steps != 1 {
+			t.Errorf("Expected %d, got %d", 1, steps)
+		}
+	})
+
+	t.Run("notifies every observer when one removes itself", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		calls := []int{0, 0, 0}
+		var remove func()
+		remove = s.Observe(StepHandler(func(_ *Sim, data IterationData) {
+			calls[0]++
+			remove()
+		}))
+		for index := 1; index < len(calls); index++ {
+			index := index
+			s.Observe(StepHandler(func(_ *Sim, data IterationData) {
+				calls[index]++
+			}))
+		}
+
+		// When
+		s.RunStep(context.TODO())
+
+		// Then
+		for index, count := range calls {
+			if count != 1 {
+				t.Errorf("Expected observer %d to be called %d times, got %d", index, 1, count)
+			}
+		}
+	})
+
+	t.Run("drops notifications when channel is full", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		c := NewChannel(1)
+		s.Observe(StepHandler(c.OnStep))
+
+		// When
+		s.RunStep(context.TODO())
+		s.RunStep(context.TODO())
+
+		// Then
+		if c.GetDropped() != 1 {
+			t.Errorf("Expected %d, got %d", 1, c.GetDropped())
+		}
+		notification := <-c.C
+		if notification.Data == nil || notification.Data.Iteration != 1 {
+			t.Error("Expected first step data")
+		}
+	})
+
+	t.Run("calls async observer", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		done := make(chan int)
+		c := Async(StepHandler(func(_ *Sim, data IterationData) {
+			done <- data.Iteration
+		}), 10)
+		remove := s.Observe(c)
+
+		// When
+		s.RunStep(context.TODO())
+		remove()
+		c.Close()
+
+		// Then
+		if iteration := <-done; iteration != 1 {
+			t.Errorf("Expected %d, got %d", 1, iteration)
+		}
+	})
+}
@@ -2,7 +2,6 @@ package sim
 
 import (
 	"context"
-	"math"
 	"math/rand"
 
 	"github.com/golang/geo/r2"
@@ -23,8 +22,9 @@ type Organism struct {
 	speciesID int
 	species   *Species
 
-	bornAt int
-	diedAt int
+	bornAt     int
+	diedAt     int
+	deathCause DeathCause
 }
 
 func (o *Organism) eat(e Environment, iteration int) int {
@@ -66,6 +66,7 @@ func (o *Organism) eat(e Environment, iteration int) int {
 }
 
 func (o *Organism) procreate(
+	rng *rand.Rand,
 	canProcreate bool,
 	iteration int,
 	maxCells int,
@@ -73,7 +74,7 @@ func (o *Organism) procreate(
 ) {
 	for cellIndex, cell := range o.cells.GetAlive() {
 		if len(o.cells) >= maxCells ||
-			!(cell.shouldProcreate(iteration) || force) {
+			!(cell.shouldProcreate(rng, iteration) || force) {
 			return
 		}
 
@@ -85,9 +86,9 @@ func (o *Organism) procreate(
 		}
 
 		if len(producedCt) > 0 {
-			freeSpot := getFreeSpot(o.cells, cell, cell.cellType.CanConnect())
+			freeSpot := getFreeSpot(rng, o.cells, cell, cell.cellType.CanConnect())
 			if freeSpot != nil {
-				child := o.cells[cellIndex].procreate(iteration, producedCt)
+				child := o.cells[cellIndex].procreate(rng, iteration, producedCt)
 				o.lastCellId++
 				child.id = o.lastCellId
 				child.position = *freeSpot
@@ -99,12 +100,12 @@ func (o *Organism) procreate(
 	}
 }
 
-func (o Organism) shouldMutate() bool {
-	return rand.Float32() > .999
+func (o Organism) shouldMutate(rng *rand.Rand, mutationRate float64) bool {
+	return rng.Float64() < mutationRate
 }
 
-func (o *Organism) mutate(addSpecies AddSpecies) {
-	newSpecies := o.species.mutate()
+func (o *Organism) mutate(rng *rand.Rand, addSpecies AddSpecies) {
+	newSpecies := o.species.mutate(rng)
 	o.species = addSpecies(newSpecies)
 	o.speciesID = o.species.id
 
@@ -129,12 +130,12 @@ func (o *Organism) die(iteration int) {
 	}
 }
 
-func (o *Organism) move() r2.Point {
+func (o *Organism) move(rng *rand.Rand) r2.Point {
 	var moveVec r2.Point
 
 	if o.action == idle {
-		if rand.Float32() > .9 {
-			o.angle = getRandomAngle()
+		if rng.Float32() > .9 {
+			o.angle = getRandomAngle(rng)
 		}
 		moveVec = getVecFromAngle(o.angle)
 	} else {
@@ -155,14 +156,17 @@ func (o *Organism) move() r2.Point {
 func (o *Organism) killCells(env Environment, iteration int) {
 	for cellIndex := range o.cells {
 		cell := &o.cells[cellIndex]
-		if cell.shouldDie(env, iteration, o.position) {
+		cause := cell.getDeathCause(env, iteration, o.position)
+		if cause != "" {
 			cell.die(iteration)
+			o.deathCause = cause
 		}
 	}
 }
 
 func (o *Organism) split(
 	ctx context.Context,
+	rng *rand.Rand,
 	canProcreate bool,
 	iteration int,
 ) []Organism {
@@ -285,7 +289,7 @@ func (o *Organism) split(
 			organisms[gridIndex] = *o
 			organisms[gridIndex].cells = grid
 			organisms[gridIndex].position = o.position.Add(center)
-			organisms[gridIndex].angle = rand.Float64() * 2 * math.Pi
+			organisms[gridIndex].angle = getRandomAngle(rng)
 			organisms[gridIndex].lastCellId = len(grid) - 1
 		}
 
@@ -297,27 +301,33 @@ func (o *Organism) split(
 
 func (o *Organism) sim(
 	ctx context.Context,
+	rng *rand.Rand,
 	env Environment,
 	iteration int,
 	maxCells int,
+	mutationRate float64,
 	addSpecies AddSpecies,
 	canProcreate bool,
 ) OrganismList {
 	age := iteration - o.bornAt
 	if o.IsAlive() {
 		o.eat(env, iteration)
-		o.move()
+		o.move(rng)
 
-		if o.shouldMutate() {
-			o.mutate(addSpecies)
+		if o.shouldMutate(rng, mutationRate) {
+			o.mutate(rng, addSpecies)
 		}
 
 		if age < 3 || age > 200+iteration/3200 {
-			if rand.Float64() > .66 {
+			if rng.Float64() > .66 {
 				o.die(iteration)
+				o.deathCause = DeathCauseAge
+				if age < 3 {
+					o.deathCause = DeathCauseInfancy
+				}
 			}
 		} else {
-			o.procreate(canProcreate, iteration, maxCells, false)
+			o.procreate(rng, canProcreate, iteration, maxCells, false)
 			o.killCells(env, iteration)
 		}
 
@@ -326,7 +336,7 @@ func (o *Organism) sim(
 			return []Organism{}
 		}
 
-		return o.split(ctx, canProcreate, iteration)
+		return o.split(ctx, rng, canProcreate, iteration)
 	}
 
 	return OrganismList{}
@@ -337,7 +347,7 @@ func (o *Organism) sim(
 func (o Organism) GetMass() int {
 	mass := 0
 	for cellIndex := range o.cells {
-		mass += o.cells[cellIndex].cellType.getMass()
+		mass += o.cells[cellIndex].cellType.GetMass()
 	}
 
 	return mass
@@ -353,9 +363,24 @@ func (o Organism) GetMobility() int {
 func (o Organism) GetPosition() r2.Point {
 	return o.position
 }
+func (o Organism) GetAngle() float64 {
+	return o.angle
+}
+func (o Organism) GetAction() Action {
+	return o.action
+}
+func (o Organism) GetTarget() r2.Point {
+	return o.target
+}
+func (o Organism) GetDiedAt() int {
+	return o.diedAt
+}
 func (o Organism) GetBornAt() int {
 	return o.bornAt
 }
+func (o Organism) GetDeathCause() DeathCause {
+	return o.deathCause
+}
 func (o Organism) GetCells() CellList {
 	return o.cells
 }
@@ -366,30 +391,45 @@ func (o Organism) GetSpecies() Species {
 	return *o.species
 }
 
-func getRandomOrganism(id int, e Environment, addSpecies AddSpecies) Organism {
-	s := addSpecies(getRandomHerbivore())
+func getRandomOrganism(
+	rng *rand.Rand,
+	id int,
+	e Environment,
+	addSpecies AddSpecies,
+) Organism {
+	s := addSpecies(getRandomHerbivore(rng))
+
+	return newOrganism(rng, id, s, r2.Point{
+		X: float64(e.width)*rng.Float64()*.8 + float64(e.width)/10,
+		Y: float64(e.height)*rng.Float64()*.8 + float64(e.height)/10,
+	})
+}
+
+// newOrganism creates organism made of single cell of species' first type
+func newOrganism(
+	rng *rand.Rand,
+	id int,
+	s *Species,
+	position r2.Point,
+) Organism {
 	ct := &s.types[0]
 
 	c := Cell{
 		alive:     true,
 		cellType:  ct,
 		satiation: 20,
-		hp:        ct.getMaxHP(),
+		hp:        ct.GetMaxHP(),
 	}
 
 	return Organism{
-		id:     id,
-		angle:  rand.Float64() * 2 * math.Pi,
-		cells:  CellList{c},
-		action: idle,
-		position: r2.Point{
-			X: float64(e.width)*rand.Float64()*.8 + float64(e.width)/10,
-			Y: float64(e.height)*rand.Float64()*.8 + float64(e.height)/10,
-		},
+		id:        id,
+		angle:     getRandomAngle(rng),
+		cells:     CellList{c},
+		action:    idle,
+		position:  position,
 		species:   s,
 		speciesID: s.id,
 	}
-
 }
 
 type OrganismList []Organism
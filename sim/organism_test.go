@@ -2,6 +2,7 @@ package sim
 
 import (
 	"context"
+	"math/rand"
 	"testing"
 
 	"github.com/golang/geo/r2"
@@ -11,27 +12,31 @@ func addSpecies(s Species) *Species {
 	return &s
 }
 
+func getRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
 func TestOrganismSplitting(t *testing.T) {
 	// Given
 	o := Organism{
 		action: idle,
 		cells: CellList{{
 			id:       0,
-			position: r2.Point{0, 0},
+			position: r2.Point{X: 0, Y: 0},
 		}, {
 			id:       1,
-			position: r2.Point{1, 0},
+			position: r2.Point{X: 1, Y: 0},
 		}, {
 			id:       2,
-			position: r2.Point{1, 1},
+			position: r2.Point{X: 1, Y: 1},
 		}, {
 			id:       3,
-			position: r2.Point{2, 2},
+			position: r2.Point{X: 2, Y: 2},
 		}},
 	}
 
 	// When
-	os := o.split(context.TODO(), true, 1)
+	os := o.split(context.TODO(), getRand(), true, 1)
 
 	// Then
 	if len(os) != 1 {
@@ -182,14 +187,14 @@ func TestOrganismDyingFromOutOfBounds(t *testing.T) {
 			alive:     true,
 			cellType:  &ct,
 			hp:        1,
-			position:  r2.Point{1, 1},
+			position:  r2.Point{X: 1, Y: 1},
 			satiation: 1,
 		}, {
 			id:        1,
 			alive:     true,
 			cellType:  &ct,
 			hp:        1,
-			position:  r2.Point{-1, 1},
+			position:  r2.Point{X: -1, Y: 1},
 			satiation: 1,
 		}},
 	}
@@ -313,11 +318,12 @@ func TestOrganismMutation(t *testing.T) {
 		}
 
 		// When
-		o1.procreate(true, 1, 25, true)
-		os := o1.split(context.TODO(), true, 1)
+		rng := getRand()
+		o1.procreate(rng, true, 1, 25, true)
+		os := o1.split(context.TODO(), rng, true, 1)
 		o2 := os[0]
-		o2.mutate(addSpecies)
-		o2.species.types[0].mutateDiet()
+		o2.mutate(rng, addSpecies)
+		o2.species.types[0].mutateDiet(rng)
 
 		// Then
 		if o1.species == o2.species {
@@ -349,7 +355,7 @@ func TestOrganismMutation(t *testing.T) {
 func TestRandomOrganism(t *testing.T) {
 	t.Run("creates copy of species", func(t *testing.T) {
 		// Given
-		s := getRandomHerbivore()
+		s := getRandomHerbivore(getRand())
 		s.types[0].connects = 0
 		o1 := Organism{
 			species: &s,
@@ -364,11 +370,12 @@ func TestRandomOrganism(t *testing.T) {
 		}
 
 		// When
-		o1.procreate(true, 1, 25, true)
-		os := o1.split(context.TODO(), true, 1)
+		rng := getRand()
+		o1.procreate(rng, true, 1, 25, true)
+		os := o1.split(context.TODO(), rng, true, 1)
 		o2 := os[0]
-		o2.mutate(addSpecies)
-		o2.species.types[0].mutateDiet()
+		o2.mutate(rng, addSpecies)
+		o2.species.types[0].mutateDiet(rng)
 
 		// Then
 		if o1.species == o2.species {
@@ -446,8 +453,9 @@ func TestOrganismProcreation(t *testing.T) {
 		}
 
 		// When
+		rng := getRand()
 		for i := 0; i < 50; i++ {
-			o.procreate(true, 0, 50, true)
+			o.procreate(rng, true, 0, 50, true)
 		}
 
 		// Then
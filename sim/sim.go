@@ -3,7 +3,10 @@ package sim
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/geo/r2"
@@ -13,25 +16,133 @@ import (
 
 type SimConfig struct {
 	EnvDivisions       int
+	EnvHeight          int
+	EnvToxicity        float64
+	EnvWidth           int
 	MaxCellsInOrganism int
 	MaxOrganisms       int
-	StartCells         int
-	Verbose            bool
-	WarmupIterations   int
+	// Default is used if it's nil, so 0 can disable mutations
+	MutationRate     *float64
+	Seed             int64
+	StartCells       int
+	Verbose          bool
+	WarmupIterations int
+	// Steps run by RunLoop once warmup is over
+	StepsPerSecond float64
+}
+
+// Zero values are replaced with defaults
+const (
+	defaultEnvHeight      = 10000
+	defaultEnvToxicity    = 4
+	defaultEnvWidth       = 10000
+	defaultMutationRate   = .001
+	defaultStepsPerSecond = 1
+)
+
+// Upper bounds of config, sim allocates memory proportional to them up front
+const (
+	maxEnvSize              int = 1e6
+	maxEnvDivisions         int = 1e3
+	maxOrganismsLimit       int = 1e5
+	maxCellsInOrganismLimit int = 1e3
+)
+
+func (c SimConfig) withDefaults() SimConfig {
+	if c.EnvHeight == 0 {
+		c.EnvHeight = defaultEnvHeight
+	}
+	if c.EnvToxicity == 0 {
+		c.EnvToxicity = defaultEnvToxicity
+	}
+	if c.EnvWidth == 0 {
+		c.EnvWidth = defaultEnvWidth
+	}
+	if c.MutationRate == nil {
+		mutationRate := float64(defaultMutationRate)
+		c.MutationRate = &mutationRate
+	}
+	if c.StepsPerSecond == 0 {
+		c.StepsPerSecond = defaultStepsPerSecond
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+
+	return c
+}
+
+// ConfigError lists every invalid parameter of SimConfig
+type ConfigError []string
+
+func (e ConfigError) Error() string {
+	return strings.Join(e, ", ")
+}
+
+func (e *ConfigError) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		*e = append(*e, fmt.Sprintf(format, args...))
+	}
+}
+
+// Validate checks config before sim is created from it, zero values are
+// replaced with defaults first. Returned error is ConfigError.
+func (c SimConfig) Validate() error {
+	c = c.withDefaults()
+
+	errs := ConfigError{}
+	errs.check(
+		c.EnvWidth > 0 && c.EnvHeight > 0 &&
+			c.EnvWidth <= maxEnvSize && c.EnvHeight <= maxEnvSize,
+		"envWidth and envHeight must be between 1 and %d, got %dx%d",
+		maxEnvSize,
+		c.EnvWidth,
+		c.EnvHeight,
+	)
+	errs.check(c.EnvToxicity >= 0, "envToxicity must not be negative, got %g", c.EnvToxicity)
+	errs.check(
+		*c.MutationRate >= 0 && *c.MutationRate <= 1,
+		"mutationRate must be between 0 and 1, got %g",
+		*c.MutationRate,
+	)
+	errs.check(
+		c.StartCells > 0 && c.StartCells <= maxOrganismsLimit,
+		"startCells must be between 1 and %d, got %d",
+		maxOrganismsLimit,
+		c.StartCells,
+	)
+	errs = append(errs, c.validateTunables()...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
 }
 
 type Sim struct {
 	areaCount          int
+	commands           chan queuedCommand
+	config             SimConfig
+	data               IterationData
 	env                Environment
 	iteration          int
 	lock               sync.Mutex
+	looping            int32
 	maxCells           int
 	maxCellsInOrganism int
+	mutationRate       float64
+	observerLastID     int
+	observers          []observerEntry
 	organismLastID     int
 	organisms          OrganismList
+	rng                *rand.Rand
+	snapshot           atomic.Value
+	source             *countingSource
 	species            SpeciesList
 	speciesLastID      int
 	speciesLock        sync.Mutex
+	stepDurations      StepDurations
 	verbose            bool
 	warmupIterations   int
 }
@@ -42,6 +153,7 @@ func (d *IterationData) from(from IterationData) {
 	d.Iteration = from.Iteration
 	d.Procreation = from.Procreation
 	d.Waste = from.Waste
+	d.Stats = from.Stats
 }
 
 func (s *Sim) GetNewOrganismID() int {
@@ -49,19 +161,23 @@ func (s *Sim) GetNewOrganismID() int {
 	return s.organismLastID
 }
 
-func (s Sim) GetEnvironment() Environment {
+func (s *Sim) GetConfig() SimConfig {
+	return s.config
+}
+
+func (s *Sim) GetEnvironment() Environment {
 	return s.env
 }
 
-func (s Sim) GetIteration() int {
+func (s *Sim) GetIteration() int {
 	return s.iteration
 }
 
-func (s Sim) GetAliveCount() int {
+func (s *Sim) GetAliveCount() int {
 	return s.organisms.GetAliveCount()
 }
 
-func (s Sim) GetCellCount() int {
+func (s *Sim) GetCellCount() int {
 	return len(s.organisms)
 }
 
@@ -69,10 +185,14 @@ func (s *Sim) GetOrganisms() OrganismList {
 	return s.organisms
 }
 
-func (s Sim) GetSpecies() SpeciesList {
+func (s *Sim) GetSpecies() SpeciesList {
 	return s.species
 }
 
+func (s *Sim) GetStepDurations() StepDurations {
+	return s.stepDurations
+}
+
 func (s *Sim) Lock() {
 	s.lock.Lock()
 }
@@ -91,6 +211,9 @@ func (s *Sim) addSpecies(species Species) *Species {
 	s.speciesLastID++
 	s.species = append(s.species, sp)
 	s.speciesLock.Unlock()
+
+	s.emitSpecies(EventSpeciesEmerged, sp, nil)
+
 	return &s.species[len(s.species)-1]
 }
 
@@ -126,6 +249,9 @@ func (s *Sim) cleanupSpecies(ctx context.Context) {
 
 		if !found || count == 0 {
 			idsToDelete = append(idsToDelete, s.species[speciesIndex].id)
+			s.emitSpecies(EventSpeciesExtinct, species, map[string]interface{}{
+				"age": s.iteration - species.emergedAt,
+			})
 		}
 		s.species[speciesIndex].extinct = false
 		s.species[speciesIndex].count = count
@@ -139,6 +265,13 @@ func (s *Sim) cleanupSpecies(ctx context.Context) {
 		spanCtx,
 		"reindex",
 	)
+	s.reindexSpecies()
+	reindexSpan.Finish()
+}
+
+// reindexSpecies points organisms to their species, which may be moved when
+// species are added or removed
+func (s *Sim) reindexSpecies() {
 	speciesMap := make(map[int]*Species, len(s.species))
 	for speciesIndex := range s.species {
 		speciesMap[s.species[speciesIndex].id] = &s.species[speciesIndex]
@@ -146,10 +279,9 @@ func (s *Sim) cleanupSpecies(ctx context.Context) {
 	for organismIndex, organism := range s.organisms {
 		s.organisms[organismIndex].species = speciesMap[organism.speciesID]
 	}
-	reindexSpan.Finish()
 }
 
-func (s Sim) getAreas(ctx context.Context) []bool {
+func (s *Sim) getAreas(ctx context.Context) []bool {
 	span, spanCtx := opentracing.StartSpanFromContext(
 		ctx,
 		"get-areas",
@@ -199,14 +331,21 @@ func (s Sim) getAreas(ctx context.Context) []bool {
 }
 
 func (s *Sim) Create(config SimConfig) {
+	config = config.withDefaults()
+
+	s.config = config
+	s.source = newCountingSource(config.Seed)
+	s.rng = rand.New(s.source)
 	s.iteration = 0
-	s.env = Environment{4, 10000, 10000}
+	s.env = Environment{config.EnvToxicity, config.EnvWidth, config.EnvHeight}
 
 	startCells := make(OrganismList, config.StartCells)
 
 	for i := 0; i < config.StartCells; i++ {
-		startCells[i] = getRandomOrganism(i, s.env, s.addSpecies)
+		startCells[i] = getRandomOrganism(s.rng, i, s.env, s.addSpecies)
 	}
+	// Descendants must not reuse start organism IDs
+	s.organismLastID = config.StartCells - 1
 
 	s.organisms = startCells
 	s.maxCells = config.MaxOrganisms
@@ -214,6 +353,27 @@ func (s *Sim) Create(config SimConfig) {
 	s.verbose = config.Verbose
 	s.warmupIterations = config.WarmupIterations
 	s.maxCellsInOrganism = config.MaxCellsInOrganism
+	s.mutationRate = *config.MutationRate
+	s.commands = make(chan queuedCommand, commandQueueSize)
+
+	s.data = IterationData{
+		AliveCellCount: len(startCells),
+		CellCount:      len(startCells),
+		Waste:          WasteData{Waste: s.env.toxicity},
+		Procreation:    ProcreationData{Species: s.species},
+	}
+	s.publish()
+}
+
+// getStepSeed mixes sim seed with iteration, so that steps of sims with close
+// seeds don't draw the same numbers
+func getStepSeed(seed int64, iteration int) int64 {
+	x := uint64(seed) ^ uint64(iteration)*0x9e3779b97f4a7c15
+	x ^= x >> 31
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 29
+
+	return int64(x)
 }
 
 func (s *Sim) RunStep(ctx context.Context) IterationData {
@@ -227,10 +387,17 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 		log.Int("Species", len(s.species)),
 	)
 	defer span.Finish()
+	stepStart := time.Now()
 
 	s.iteration++
+	s.source.Seed(getStepSeed(s.config.Seed, s.iteration))
 
-	nextGenOrganisms := make(OrganismList, s.maxCells*5)
+	// Organisms may outnumber the limit for a while after it's lowered by Tune
+	maxOrganisms := s.maxCells
+	if len(s.organisms) > maxOrganisms {
+		maxOrganisms = len(s.organisms)
+	}
+	nextGenOrganisms := make(OrganismList, maxOrganisms*5)
 	waste := float64(0)
 
 	dataSpan, _ := opentracing.StartSpanFromContext(stepSpanCtx, "get-data")
@@ -283,8 +450,11 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 	avgPoints := allPoints / len(s.species)
 	avgConnectivity := allConnectivity / len(s.species)
 
+	phaseStart := time.Now()
 	areas := s.getAreas(stepSpanCtx)
+	s.stepDurations.GetAreas = time.Since(phaseStart)
 
+	phaseStart = time.Now()
 	simSpan, simSpanCtx := opentracing.StartSpanFromContext(stepSpanCtx, "sim")
 	removedCellCounter := 0
 	for organismIndex, organism := range s.organisms {
@@ -310,20 +480,40 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 			canProcreate = canProcreate && areas[auxArea]
 		}
 
+		wasAlive := organism.IsAlive()
 		descendants := s.organisms[organismIndex].sim(
 			simSpanCtx,
+			s.rng,
 			s.env,
 			s.iteration,
 			s.maxCellsInOrganism,
+			s.mutationRate,
 			s.addSpecies,
 			canProcreate,
 		)
 
+		if s.organisms[organismIndex].speciesID != organism.speciesID {
+			s.emit(EventMutation, s.organisms[organismIndex], map[string]interface{}{
+				"parentSpecies": organism.speciesID,
+			})
+		}
+		if wasAlive && !s.organisms[organismIndex].IsAlive() {
+			s.emit(EventDeath, s.organisms[organismIndex], map[string]interface{}{
+				"cause": s.organisms[organismIndex].deathCause,
+				"age":   s.iteration - organism.bornAt,
+			})
+		}
+
 		for dIndex := range descendants {
 			descendants[dIndex].id = s.GetNewOrganismID()
 			descendants[dIndex].bornAt = s.iteration
 			nextGenOrganisms[index] = descendants[dIndex]
 			index++
+
+			s.emit(EventBirth, descendants[dIndex], map[string]interface{}{
+				"parent": organism.id,
+				"cells":  len(descendants[dIndex].cells),
+			})
 		}
 
 		alive := false
@@ -335,7 +525,7 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 			} else {
 				if cell.alive {
 					alive = true
-					waste += cell.cellType.getWaste(s.env.getToxicityOnHeight(cell.position.Y))
+					waste += cell.cellType.getWaste(s.env.GetToxicityOnHeight(cell.position.Y))
 					data.AliveCellCount++
 				}
 			}
@@ -344,6 +534,10 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 		if len(removeMap) > 0 {
 			removedCellCounter += len(removeMap)
 			s.organisms[organismIndex].cells = organism.cells.Remove(removeMap)
+
+			s.emit(EventDecay, s.organisms[organismIndex], map[string]interface{}{
+				"cells": len(removeMap),
+			})
 		}
 
 		if alive {
@@ -357,50 +551,100 @@ func (s *Sim) RunStep(ctx context.Context) IterationData {
 	simSpan.Finish()
 
 	s.organisms = nextGenOrganisms[:index]
-	s.env.changeToxicity(waste)
+	s.AddToxicity(waste)
+	s.stepDurations.Sim = time.Since(phaseStart)
 
+	phaseStart = time.Now()
 	s.cleanupSpecies(stepSpanCtx)
+	s.stepDurations.CleanupSpecies = time.Since(phaseStart)
 
 	data.Procreation.Species = s.species
 
+	data.Stats = StatsData{
+		Organisms:           len(s.organisms),
+		Species:             len(s.GetSpecies().GetAlive()),
+		Toxicity:            s.env.toxicity,
+		HighestLevel:        highestPoints - startingPoints + 1,
+		AvgLevel:            avgPoints - startingPoints + 1,
+		HighestConnectivity: highestConnectivity,
+		AvgConnectivity:     avgConnectivity,
+		Connecting:          allConnecting,
+	}
+
 	if s.verbose {
-		fmt.Printf(
-			"It: %6d, o: %5d, w: %.4f sp: %4d, HLvl: %3d, AvgLvl: %3d, HCn: %2d, AvgCn: %2d, CCn: %2d\n",
-			s.iteration,
-			len(s.organisms),
-			s.env.toxicity,
-			len(s.GetSpecies().GetAlive()),
-			highestPoints-startingPoints+1,
-			avgPoints-startingPoints+1,
-			highestConnectivity,
-			avgConnectivity,
-			allConnecting,
-		)
+		fmt.Println(data)
+	}
+
+	s.stepDurations.Step = time.Since(stepStart)
+	s.data = data
+	s.publish()
+
+	for _, entry := range s.observers {
+		entry.observer.OnStep(s, data)
 	}
 
 	return data
 }
 
-func (s *Sim) RunLoop(data *IterationData) {
-	for {
+// RunLoop runs until sim dies out or context is cancelled. Queued commands are
+// applied before each step and while waiting for the next one.
+func (s *Sim) RunLoop(ctx context.Context, data *IterationData) {
+	atomic.StoreInt32(&s.looping, 1)
+	defer func() {
+		// Commands queued while loop was stopping would never be applied
+		atomic.StoreInt32(&s.looping, 0)
+		s.lock.Lock()
+		s.runCommands()
+		s.lock.Unlock()
+	}()
+
+	for ctx.Err() == nil {
 		spanName := fmt.Sprintf("loop %d", data.Iteration+1)
 		span := opentracing.GlobalTracer().StartSpan(spanName)
-		ctx := opentracing.ContextWithSpan(context.Background(), span)
+		spanCtx := opentracing.ContextWithSpan(context.Background(), span)
 
 		s.lock.Lock()
-		iterationData := s.RunStep(ctx)
+		s.runCommands()
+		iterationData := s.RunStep(spanCtx)
 		data.from(iterationData)
+		extinct := s.GetCellCount() == 0
 
 		s.lock.Unlock()
 
-		if s.GetCellCount() == 0 {
+		span.Finish()
+
+		if extinct {
 			break
 		}
 
-		span.Finish()
-
 		if iterationData.Iteration > s.warmupIterations {
-			time.Sleep(time.Second)
+			s.wait(ctx)
+		}
+	}
+}
+
+// wait applies commands as soon as they're queued until it's time for the next
+// step. Interval is read again after each command, because it may be tuned.
+func (s *Sim) wait(ctx context.Context) {
+	start := time.Now()
+	timer := time.NewTimer(s.getStepInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case command := <-s.commands:
+			s.lock.Lock()
+			s.runCommands(command)
+			s.lock.Unlock()
+
+			if !timer.Stop() {
+				return
+			}
+			timer.Reset(s.getStepInterval() - time.Since(start))
 		}
 	}
 }
@@ -0,0 +1,60 @@
+package sim
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	getConfig := func() SimConfig {
+		return SimConfig{
+			EnvDivisions:       4,
+			MaxCellsInOrganism: 25,
+			MaxOrganisms:       1e3,
+			StartCells:         10,
+		}
+	}
+
+	t.Run("accepts config at upper bounds", func(t *testing.T) {
+		// Given
+		config := getConfig()
+		config.EnvWidth = maxEnvSize
+		config.EnvHeight = maxEnvSize
+		config.EnvDivisions = maxEnvDivisions
+		config.MaxCellsInOrganism = maxCellsInOrganismLimit
+		config.MaxOrganisms = maxOrganismsLimit
+		config.StartCells = maxOrganismsLimit
+
+		// When
+		err := config.Validate()
+
+		// Then
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	invalidConfigs := map[string]func(c *SimConfig){
+		"too wide environment":       func(c *SimConfig) { c.EnvWidth = maxEnvSize + 1 },
+		"too high environment":       func(c *SimConfig) { c.EnvHeight = maxEnvSize + 1 },
+		"too many divisions":         func(c *SimConfig) { c.EnvDivisions = maxEnvDivisions + 1 },
+		"too many start cells":       func(c *SimConfig) { c.StartCells = maxOrganismsLimit + 1 },
+		"too many organisms":         func(c *SimConfig) { c.MaxOrganisms = maxOrganismsLimit + 1 },
+		"too many cells in organism": func(c *SimConfig) { c.MaxCellsInOrganism = maxCellsInOrganismLimit + 1 },
+		"non-positive organisms":     func(c *SimConfig) { c.MaxOrganisms = 0 },
+	}
+
+	for name, modify := range invalidConfigs {
+		modify := modify
+		t.Run("rejects "+name, func(t *testing.T) {
+			// Given
+			config := getConfig()
+			modify(&config)
+
+			// When
+			err := config.Validate()
+
+			// Then
+			if err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
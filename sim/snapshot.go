@@ -0,0 +1,85 @@
+package sim
+
+// Snapshot is read-only copy of sim state published after each step and
+// command, so it can be read without locking sim
+type Snapshot struct {
+	config    SimConfig
+	data      IterationData
+	env       Environment
+	iteration int
+	organisms OrganismList
+	species   SpeciesList
+}
+
+func (s *Snapshot) GetConfig() SimConfig {
+	return s.config
+}
+
+// GetData returns data of the last step
+func (s *Snapshot) GetData() IterationData {
+	return s.data
+}
+
+func (s *Snapshot) GetEnvironment() Environment {
+	return s.env
+}
+
+func (s *Snapshot) GetIteration() int {
+	return s.iteration
+}
+
+func (s *Snapshot) GetAliveCount() int {
+	return s.organisms.GetAliveCount()
+}
+
+func (s *Snapshot) GetCellCount() int {
+	return len(s.organisms)
+}
+
+func (s *Snapshot) GetOrganisms() OrganismList {
+	return s.organisms
+}
+
+func (s *Snapshot) GetSpecies() SpeciesList {
+	return s.species
+}
+
+// GetSnapshot returns the latest published snapshot, it's safe to call without
+// holding sim lock
+func (s *Sim) GetSnapshot() *Snapshot {
+	snapshot, _ := s.snapshot.Load().(*Snapshot)
+	return snapshot
+}
+
+// publish must be called while holding sim lock. Cells are copied because
+// they're modified in place during step, cell types and species' genes never
+// change once created.
+func (s *Sim) publish() {
+	snapshot := &Snapshot{
+		config:    s.config,
+		data:      s.data,
+		env:       s.env,
+		iteration: s.iteration,
+		organisms: make(OrganismList, len(s.organisms)),
+		species:   make(SpeciesList, len(s.species)),
+	}
+
+	copy(snapshot.species, s.species)
+	speciesMap := make(map[int]*Species, len(snapshot.species))
+	for speciesIndex := range snapshot.species {
+		speciesMap[snapshot.species[speciesIndex].id] = &snapshot.species[speciesIndex]
+	}
+
+	for organismIndex, organism := range s.organisms {
+		organism.cells = make(CellList, len(organism.cells))
+		copy(organism.cells, s.organisms[organismIndex].cells)
+		if species, ok := speciesMap[organism.speciesID]; ok {
+			organism.species = species
+		}
+		snapshot.organisms[organismIndex] = organism
+	}
+
+	snapshot.data.Procreation.Species = snapshot.species
+
+	s.snapshot.Store(snapshot)
+}
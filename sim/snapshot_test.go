@@ -0,0 +1,104 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("is not modified by next steps", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		s.RunStep(context.TODO())
+		snapshot := s.GetSnapshot()
+		hp := snapshot.GetOrganisms()[0].GetCells()[0].GetHP()
+
+		// When
+		for it := 0; it < 10; it++ {
+			s.RunStep(context.TODO())
+		}
+
+		// Then
+		if snapshot.GetIteration() != 1 {
+			t.Errorf("Expected %d, got %d", 1, snapshot.GetIteration())
+		}
+		if got := snapshot.GetOrganisms()[0].GetCells()[0].GetHP(); got != hp {
+			t.Errorf("Expected %d, got %d", hp, got)
+		}
+		if s.GetSnapshot().GetIteration() != 11 {
+			t.Errorf("Expected %d, got %d", 11, s.GetSnapshot().GetIteration())
+		}
+	})
+
+	t.Run("includes changes made by command", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		snapshot, err := s.Exec(context.TODO(), func(s *Sim) error {
+			s.SetToxicity(10)
+			return nil
+		})
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snapshot.GetEnvironment().GetToxicity() != 10 {
+			t.Errorf("Expected %d, got %f", 10, snapshot.GetEnvironment().GetToxicity())
+		}
+		if s.GetSnapshot() != snapshot {
+			t.Error("Expected snapshot to be published")
+		}
+	})
+
+	t.Run("returns command error", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+
+		// When
+		_, err := s.Exec(context.TODO(), func(s *Sim) error {
+			return fmt.Errorf("Command failed")
+		})
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+
+	t.Run("applies command between steps of running loop", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			s.RunLoop(ctx, &IterationData{})
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		// When
+		start := time.Now()
+		snapshot, err := s.Exec(context.TODO(), func(s *Sim) error {
+			s.SetToxicity(10)
+			return nil
+		})
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if snapshot.GetEnvironment().GetToxicity() != 10 {
+			t.Errorf("Expected %d, got %f", 10, snapshot.GetEnvironment().GetToxicity())
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("Expected command to be applied without waiting for next step")
+		}
+	})
+}
@@ -50,20 +50,20 @@ func (s Species) getMaxTypes() int {
 	return (s.points-startingPoints)/30 + 1
 }
 
-func (s Species) mutate() Species {
+func (s Species) mutate(rng *rand.Rand) Species {
 	n := s.copy()
 	n.points++
 
 	typeCount := len(n.types)
-	typeIndex := rand.Intn(typeCount)
-	mutatedType := n.types[typeIndex].copy().mutate()
+	typeIndex := rng.Intn(typeCount)
+	mutatedType := n.types[typeIndex].copy().mutate(rng)
 	n.types[typeIndex] = mutatedType
 
 	if s.getMaxTypes() > len(s.types) {
 		ct := startingCellType.copy()
 		ct.ID = s.types[len(s.types)-1].ID + 1
 		for ct.points > ct.getInvestedPoints() {
-			ct = ct.mutateOnce()
+			ct = ct.mutateOnce(rng)
 		}
 
 		n.types = append(n.types, ct)
@@ -75,11 +75,11 @@ func (s Species) mutate() Species {
 	return n
 }
 
-func getRandomHerbivore() Species {
+func getRandomHerbivore(rng *rand.Rand) Species {
 	ct := startingCellType.copy()
 
 	for ct.points > ct.getInvestedPoints() {
-		ct = ct.mutateOnce()
+		ct = ct.mutateOnce(rng)
 	}
 
 	types := []CellType{ct}
@@ -136,6 +136,19 @@ func (s Species) GetTypes() []CellType {
 	return s.types
 }
 
+func (s Species) GetPoints() int {
+	return s.points
+}
+
+func (s Species) GetCount() int {
+	return s.count
+}
+
+// GetProduces returns IDs of cell types which can be produced by each type
+func (s Species) GetProduces() [][]int {
+	return s.produces
+}
+
 type SpeciesList []Species
 
 func (sl SpeciesList) GetAlive() SpeciesList {
@@ -18,7 +18,7 @@ func TestMutation(t *testing.T) {
 		}
 
 		// When
-		newSpecies := s.mutate()
+		newSpecies := s.mutate(getRand())
 
 		// Then
 		if &newSpecies == &s {
@@ -53,7 +53,7 @@ func TestMutation(t *testing.T) {
 		}
 
 		// When
-		newSpecies := s.mutate()
+		newSpecies := s.mutate(getRand())
 
 		// Then
 		if newSpecies.getMaxTypes() != 2 {
@@ -0,0 +1,145 @@
+package sim
+
+import (
+	"fmt"
+	"time"
+)
+
+// Change describes parameter changed by Tune
+type Change struct {
+	Name string
+	From interface{}
+	To   interface{}
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Name, c.From, c.To)
+}
+
+// Tunable parameters are read by every step, so they can be changed between
+// steps without restarting sim
+var tunables = []struct {
+	name string
+	get  func(c *SimConfig) interface{}
+	set  func(dst *SimConfig, src SimConfig)
+}{
+	{
+		"envDivisions",
+		func(c *SimConfig) interface{} { return c.EnvDivisions },
+		func(dst *SimConfig, src SimConfig) { dst.EnvDivisions = src.EnvDivisions },
+	},
+	{
+		"maxCellsInOrganism",
+		func(c *SimConfig) interface{} { return c.MaxCellsInOrganism },
+		func(dst *SimConfig, src SimConfig) { dst.MaxCellsInOrganism = src.MaxCellsInOrganism },
+	},
+	{
+		"maxOrganisms",
+		func(c *SimConfig) interface{} { return c.MaxOrganisms },
+		func(dst *SimConfig, src SimConfig) { dst.MaxOrganisms = src.MaxOrganisms },
+	},
+	{
+		"stepsPerSecond",
+		func(c *SimConfig) interface{} { return c.StepsPerSecond },
+		func(dst *SimConfig, src SimConfig) { dst.StepsPerSecond = src.StepsPerSecond },
+	},
+	{
+		"verbose",
+		func(c *SimConfig) interface{} { return c.Verbose },
+		func(dst *SimConfig, src SimConfig) { dst.Verbose = src.Verbose },
+	},
+	{
+		"warmupIterations",
+		func(c *SimConfig) interface{} { return c.WarmupIterations },
+		func(dst *SimConfig, src SimConfig) { dst.WarmupIterations = src.WarmupIterations },
+	},
+}
+
+// IsTunable returns true if parameter can be changed by Tune
+func IsTunable(name string) bool {
+	for _, tunable := range tunables {
+		if tunable.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateTunables must be called on config with defaults applied
+func (c SimConfig) validateTunables() ConfigError {
+	maxDivisions := c.EnvWidth / 2
+	if c.EnvHeight < c.EnvWidth {
+		maxDivisions = c.EnvHeight / 2
+	}
+	if maxDivisions > maxEnvDivisions {
+		maxDivisions = maxEnvDivisions
+	}
+
+	errs := ConfigError{}
+	errs.check(
+		c.EnvDivisions > 0 && c.EnvDivisions <= maxDivisions,
+		"envDivisions must be between 1 and %d, got %d",
+		maxDivisions,
+		c.EnvDivisions,
+	)
+	errs.check(
+		c.MaxCellsInOrganism > 0 && c.MaxCellsInOrganism <= maxCellsInOrganismLimit,
+		"maxCellsInOrganism must be between 1 and %d, got %d",
+		maxCellsInOrganismLimit,
+		c.MaxCellsInOrganism,
+	)
+	errs.check(
+		c.MaxOrganisms > 0 && c.MaxOrganisms <= maxOrganismsLimit,
+		"maxOrganisms must be between 1 and %d, got %d",
+		maxOrganismsLimit,
+		c.MaxOrganisms,
+	)
+	errs.check(c.StepsPerSecond > 0, "stepsPerSecond must be positive, got %g", c.StepsPerSecond)
+	errs.check(
+		c.WarmupIterations >= 0,
+		"warmupIterations must not be negative, got %d",
+		c.WarmupIterations,
+	)
+
+	return errs
+}
+
+// Tune applies tunable parameters of config, the rest of it is ignored. It's
+// meant to be called from commands passed to Exec, so changes are applied
+// between steps. Nothing is changed if any parameter is invalid.
+func (s *Sim) Tune(config SimConfig) ([]Change, error) {
+	next := s.config
+	for _, tunable := range tunables {
+		tunable.set(&next, config)
+	}
+	if next.StepsPerSecond == 0 {
+		next.StepsPerSecond = defaultStepsPerSecond
+	}
+
+	if errs := next.validateTunables(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	changes := []Change{}
+	for _, tunable := range tunables {
+		from := tunable.get(&s.config)
+		to := tunable.get(&next)
+		if from != to {
+			changes = append(changes, Change{tunable.name, from, to})
+		}
+	}
+
+	s.config = next
+	s.areaCount = next.EnvDivisions
+	s.maxCells = next.MaxOrganisms
+	s.maxCellsInOrganism = next.MaxCellsInOrganism
+	s.verbose = next.Verbose
+	s.warmupIterations = next.WarmupIterations
+
+	return changes, nil
+}
+
+func (s *Sim) getStepInterval() time.Duration {
+	return time.Duration(float64(time.Second) / s.config.StepsPerSecond)
+}
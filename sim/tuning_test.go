@@ -0,0 +1,96 @@
+package sim
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTune(t *testing.T) {
+	t.Run("applies tunable parameters", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		config := s.GetConfig()
+		config.EnvDivisions = 8
+		config.StepsPerSecond = 10
+		config.StartCells = 100
+
+		// When
+		changes, err := s.Tune(config)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 2 {
+			t.Errorf("Expected %d, got %d: %v", 2, len(changes), changes)
+		}
+		if s.areaCount != 8 {
+			t.Errorf("Expected %d, got %d", 8, s.areaCount)
+		}
+		if s.GetConfig().StartCells != 10 {
+			t.Errorf("Expected %d, got %d", 10, s.GetConfig().StartCells)
+		}
+		if interval := s.getStepInterval().Seconds(); interval != .1 {
+			t.Errorf("Expected %f, got %f", .1, interval)
+		}
+	})
+
+	t.Run("rejects invalid parameters without changing any", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		config := s.GetConfig()
+		config.MaxOrganisms = 10
+		config.EnvDivisions = 0
+
+		// When
+		_, err := s.Tune(config)
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+		if s.maxCells != 1e3 {
+			t.Errorf("Expected %d, got %d", 1000, s.maxCells)
+		}
+	})
+
+	t.Run("keeps running after limit is lowered below organism count", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		config := s.GetConfig()
+		config.MaxOrganisms = 1
+
+		// When
+		_, err := s.Tune(config)
+		for it := 0; it < 10; it++ {
+			s.RunStep(context.TODO())
+		}
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.GetConfig().MaxOrganisms != 1 {
+			t.Errorf("Expected %d, got %d", 1, s.GetConfig().MaxOrganisms)
+		}
+	})
+
+	t.Run("rejects limits over maximum", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		config := s.GetConfig()
+		config.MaxOrganisms = maxOrganismsLimit + 1
+		config.MaxCellsInOrganism = maxCellsInOrganismLimit + 1
+
+		// When
+		_, err := s.Tune(config)
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+		if s.maxCells != 1e3 {
+			t.Errorf("Expected %d, got %d", 1000, s.maxCells)
+		}
+	})
+}
@@ -24,7 +24,7 @@ func fitToBoundary(p r2.Point, env Environment) r2.Point {
 		y = 0
 	}
 
-	return r2.Point{x, y}
+	return r2.Point{X: x, Y: y}
 }
 
 type ByLength []r2.Point
@@ -34,12 +34,13 @@ func (a ByLength) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a ByLength) Less(i, j int) bool { return a[i].Norm() < a[j].Norm() }
 
 func getFreeSpot(
+	rng *rand.Rand,
 	cells CellList,
 	cell Cell,
 	canConnect bool,
 ) *r2.Point {
 	dist := float64(1)
-	if !canConnect || rand.Float64() > .8 {
+	if !canConnect || rng.Float64() > .8 {
 		dist = 10
 	}
 
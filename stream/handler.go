@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const keepAliveInterval = 15 * time.Second
+
+func parseInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+func writeEvent(w http.ResponseWriter, summary Summary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: step\ndata: %s\n\n", summary.Iteration, data)
+	return err
+}
+
+// ServeHTTP streams summaries as Server-Sent Events. Query parameter "every"
+// sets sampling rate, clients resume after Last-Event-ID header or
+// "lastEventId" query parameter.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	every, err := parseInt(r.URL.Query().Get("every"), 1)
+	if err != nil || every < 1 {
+		http.Error(w, "Parameter every must be positive integer", http.StatusBadRequest)
+		return
+	}
+
+	lastIDValue := r.Header.Get("Last-Event-ID")
+	if lastIDValue == "" {
+		lastIDValue = r.URL.Query().Get("lastEventId")
+	}
+	lastID, err := parseInt(lastIDValue, -1)
+	if err != nil {
+		http.Error(w, "Last event ID must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	missed, c := b.subscribe(lastID)
+	defer b.unsubscribe(c)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, summary := range missed {
+		if summary.Iteration%every == 0 {
+			if err := writeEvent(w, summary); err != nil {
+				return
+			}
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case summary, ok := <-c:
+			if !ok {
+				return
+			}
+			if summary.Iteration%every != 0 {
+				continue
+			}
+			if err := writeEvent(w, summary); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
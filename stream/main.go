@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// DefaultHistorySize is number of summaries kept for reconnecting clients
+const DefaultHistorySize = 100
+
+// Clients which can't keep up are disconnected, so they can reconnect and
+// catch up using Last-Event-ID
+const clientBufferSize = 64
+
+type Procreation struct {
+	CanProcreate bool    `json:"canProcreate"`
+	MinCd        int8    `json:"minCd"`
+	MaxCd        int8    `json:"maxCd"`
+	MinHeight    float64 `json:"minHeight"`
+	MaxHeight    float64 `json:"maxHeight"`
+}
+
+// Summary is IterationData with species list replaced by its length
+type Summary struct {
+	Iteration      int           `json:"iteration"`
+	CellCount      int           `json:"cellCount"`
+	AliveCellCount int           `json:"aliveCellCount"`
+	Species        int           `json:"species"`
+	Waste          sim.WasteData `json:"waste"`
+	Procreation    Procreation   `json:"procreation"`
+}
+
+func NewSummary(data sim.IterationData) Summary {
+	return Summary{
+		Iteration:      data.Iteration,
+		CellCount:      data.CellCount,
+		AliveCellCount: data.AliveCellCount,
+		Species:        len(data.Procreation.Species),
+		Waste:          data.Waste,
+		Procreation: Procreation{
+			CanProcreate: data.Procreation.CanProcreate,
+			MinCd:        data.Procreation.MinCd,
+			MaxCd:        data.Procreation.MaxCd,
+			MinHeight:    data.Procreation.MinHeight,
+			MaxHeight:    data.Procreation.MaxHeight,
+		},
+	}
+}
+
+// Broker fans step summaries out to connected clients. Publishing never
+// blocks, so it's safe to do while holding sim lock.
+type Broker struct {
+	lock        sync.Mutex
+	history     []Summary
+	historySize int
+	clients     map[chan Summary]bool
+}
+
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		historySize: historySize,
+		clients:     map[chan Summary]bool{},
+	}
+}
+
+func (b *Broker) Publish(summary Summary) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.history = append(b.history, summary)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for c := range b.clients {
+		select {
+		case c <- summary:
+		default:
+			delete(b.clients, c)
+			close(c)
+		}
+	}
+}
+
+// OnStep can be registered as sim step handler
+func (b *Broker) OnStep(s *sim.Sim, data sim.IterationData) {
+	b.Publish(NewSummary(data))
+}
+
+// subscribe returns summaries published after lastID and channel receiving
+// next ones. Channel is closed if client falls behind.
+func (b *Broker) subscribe(lastID int) ([]Summary, chan Summary) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	missed := []Summary{}
+	for _, summary := range b.history {
+		if summary.Iteration > lastID {
+			missed = append(missed, summary)
+		}
+	}
+
+	c := make(chan Summary, clientBufferSize)
+	b.clients[c] = true
+
+	return missed, c
+}
+
+func (b *Broker) unsubscribe(c chan Summary) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.clients[c] {
+		delete(b.clients, c)
+		close(c)
+	}
+}
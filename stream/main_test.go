@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func readEventIDs(t *testing.T, scanner *bufio.Scanner, count int) []string {
+	ids := []string{}
+	for len(ids) < count && scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return ids
+}
+
+func TestBroker(t *testing.T) {
+	t.Run("replays missed events and samples stream", func(t *testing.T) {
+		// Given
+		broker := NewBroker(DefaultHistorySize)
+		server := httptest.NewServer(broker)
+		defer server.Close()
+
+		for it := 1; it <= 6; it++ {
+			broker.OnStep(nil, sim.IterationData{Iteration: it})
+		}
+
+		// When
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"?every=2", nil)
+		req.Header.Set("Last-Event-ID", "2")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		scanner := bufio.NewScanner(res.Body)
+
+		replayed := readEventIDs(t, scanner, 2)
+		for it := 7; it <= 10; it++ {
+			broker.OnStep(nil, sim.IterationData{Iteration: it})
+		}
+		live := readEventIDs(t, scanner, 2)
+
+		// Then
+		if res.Header.Get("Content-Type") != "text/event-stream" {
+			t.Errorf("Expected %s, got %s", "text/event-stream", res.Header.Get("Content-Type"))
+		}
+		if strings.Join(replayed, ",") != "4,6" {
+			t.Errorf("Expected %s, got %s", "4,6", strings.Join(replayed, ","))
+		}
+		if strings.Join(live, ",") != "8,10" {
+			t.Errorf("Expected %s, got %s", "8,10", strings.Join(live, ","))
+		}
+	})
+
+	t.Run("disconnects slow clients", func(t *testing.T) {
+		// Given
+		broker := NewBroker(DefaultHistorySize)
+		_, c := broker.subscribe(-1)
+
+		// When
+		for it := 0; it <= clientBufferSize; it++ {
+			broker.Publish(Summary{Iteration: it})
+		}
+
+		// Then
+		received := 0
+		for range c {
+			received++
+		}
+		if received != clientBufferSize {
+			t.Errorf("Expected %d, got %d", clientBufferSize, received)
+		}
+	})
+
+	t.Run("rejects invalid sampling rate", func(t *testing.T) {
+		// Given
+		broker := NewBroker(DefaultHistorySize)
+		w := httptest.NewRecorder()
+
+		// When
+		broker.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?every=0", nil))
+
+		// Then
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/dominik-zeglen/aquarium/batch"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+var parameters = map[string]func(c *sim.SimConfig, value float64){
+	"envDivisions":       func(c *sim.SimConfig, v float64) { c.EnvDivisions = int(v) },
+	"envHeight":          func(c *sim.SimConfig, v float64) { c.EnvHeight = int(v) },
+	"envToxicity":        func(c *sim.SimConfig, v float64) { c.EnvToxicity = v },
+	"envWidth":           func(c *sim.SimConfig, v float64) { c.EnvWidth = int(v) },
+	"maxCellsInOrganism": func(c *sim.SimConfig, v float64) { c.MaxCellsInOrganism = int(v) },
+	"maxOrganisms":       func(c *sim.SimConfig, v float64) { c.MaxOrganisms = int(v) },
+	"mutationRate":       func(c *sim.SimConfig, v float64) { c.MutationRate = &v },
+	"startCells":         func(c *sim.SimConfig, v float64) { c.StartCells = int(v) },
+}
+
+// Space defines grid of parameters, every combination is run once per seed
+type Space struct {
+	Base        sim.SimConfig        `json:"base"`
+	Seeds       []int64              `json:"seeds"`
+	Iterations  int                  `json:"iterations"`
+	Parallelism int                  `json:"parallelism"`
+	Grid        map[string][]float64 `json:"grid"`
+}
+
+type Run struct {
+	Index  int
+	Seed   int64
+	Params []float64
+	Config batch.Config
+}
+
+type Result struct {
+	Run     Run
+	Summary batch.Summary
+	Err     error
+}
+
+func Load(r io.Reader) (Space, error) {
+	space := Space{}
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&space); err != nil {
+		return space, fmt.Errorf("Could not parse sweep definition: %s", err)
+	}
+
+	return space, space.validate()
+}
+
+func (sp Space) validate() error {
+	if len(sp.Seeds) == 0 {
+		return fmt.Errorf("Sweep definition must contain at least one seed")
+	}
+	if sp.Iterations <= 0 {
+		return fmt.Errorf("Sweep iterations must be positive, got %d", sp.Iterations)
+	}
+
+	for name, values := range sp.Grid {
+		if _, ok := parameters[name]; !ok {
+			return fmt.Errorf("Unknown sweep parameter %s", name)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("Sweep parameter %s has no values", name)
+		}
+	}
+
+	return nil
+}
+
+// GetParamNames returns grid parameters in stable order
+func (sp Space) GetParamNames() []string {
+	names := make([]string, 0, len(sp.Grid))
+	for name := range sp.Grid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (sp Space) GetRuns() []Run {
+	names := sp.GetParamNames()
+	combinations := [][]float64{{}}
+
+	for _, name := range names {
+		next := make([][]float64, 0, len(combinations)*len(sp.Grid[name]))
+		for _, combination := range combinations {
+			for _, value := range sp.Grid[name] {
+				params := make([]float64, len(combination), len(combination)+1)
+				copy(params, combination)
+				next = append(next, append(params, value))
+			}
+		}
+		combinations = next
+	}
+
+	runs := make([]Run, 0, len(combinations)*len(sp.Seeds))
+	for _, params := range combinations {
+		for _, seed := range sp.Seeds {
+			config := sp.Base
+			for nameIndex, name := range names {
+				parameters[name](&config, params[nameIndex])
+			}
+			config.Seed = seed
+			config.Verbose = false
+
+			runs = append(runs, Run{
+				Index:  len(runs),
+				Seed:   seed,
+				Params: params,
+				Config: batch.Config{
+					Sim:        config,
+					Iterations: sp.Iterations,
+				},
+			})
+		}
+	}
+
+	return runs
+}
+
+func Execute(ctx context.Context, space Space) []Result {
+	runs := space.GetRuns()
+	results := make([]Result, len(runs))
+
+	parallelism := space.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, parallelism)
+
+	for runIndex := range runs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(run Run) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			summary, err := batch.Run(ctx, run.Config)
+			results[run.Index] = Result{run, summary, err}
+		}(runs[runIndex])
+	}
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,144 @@
+package sweep
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+const definition = `{
+	"base": {
+		"envDivisions": 4,
+		"maxCellsInOrganism": 25,
+		"startCells": 10
+	},
+	"seeds": [1, 2],
+	"iterations": 30,
+	"parallelism": 3,
+	"grid": {
+		"maxOrganisms": [500, 1000],
+		"mutationRate": [0.001, 0.01]
+	}
+}`
+
+func TestLoad(t *testing.T) {
+	t.Run("creates run for every combination and seed", func(t *testing.T) {
+		// When
+		space, err := Load(strings.NewReader(definition))
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		runs := space.GetRuns()
+		if len(runs) != 8 {
+			t.Fatalf("Expected 8, got %d", len(runs))
+		}
+		if runs[7].Config.Sim.MaxOrganisms != 1000 ||
+			*runs[7].Config.Sim.MutationRate != .01 ||
+			runs[7].Config.Sim.Seed != 2 {
+			t.Errorf("Unexpected last run config %+v", runs[7].Config.Sim)
+		}
+	})
+
+	t.Run("rejects unknown parameters", func(t *testing.T) {
+		// When
+		_, err := Load(strings.NewReader(`{
+			"seeds": [1],
+			"iterations": 1,
+			"grid": {"foo": [1]}
+		}`))
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
+
+func TestExecute(t *testing.T) {
+	// Given
+	space, err := Load(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	results := Execute(context.TODO(), space)
+
+	// Then
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("Run %d failed: %s", result.Run.Index, result.Err)
+		}
+	}
+
+	// Same seed and parameters should give same outcome regardless of
+	// other sims running in parallel
+	again := Execute(context.TODO(), space)
+	for resultIndex := range results {
+		if results[resultIndex].Summary.Organisms != again[resultIndex].Summary.Organisms {
+			t.Errorf(
+				"Run %d is not reproducible, %d vs %d organisms",
+				resultIndex,
+				results[resultIndex].Summary.Organisms,
+				again[resultIndex].Summary.Organisms,
+			)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	if err := WriteCSV(&buf, space, results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 9 {
+		t.Errorf("Expected 9 lines, got %d", len(lines))
+	}
+}
+
+func TestMutationRate(t *testing.T) {
+	// Given
+	space, err := Load(strings.NewReader(`{
+		"base": {
+			"envDivisions": 4,
+			"maxCellsInOrganism": 25,
+			"maxOrganisms": 1000,
+			"startCells": 10
+		},
+		"seeds": [1],
+		"iterations": 100,
+		"grid": {"mutationRate": [0, 1]}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := space.GetRuns()
+	mutations := make([]int, len(runs))
+
+	// When
+	for runIndex, run := range runs {
+		s := &sim.Sim{}
+		s.Create(run.Config.Sim)
+		s.OnEvent(func(event sim.Event) {
+			if event.Type == sim.EventMutation {
+				mutations[runIndex]++
+			}
+		})
+		for it := 0; it < run.Config.Iterations; it++ {
+			s.RunStep(context.TODO())
+		}
+	}
+
+	// Then
+	if mutations[0] != 0 {
+		t.Errorf("Expected %d, got %d", 0, mutations[0])
+	}
+	if mutations[1] == 0 {
+		t.Error("Expected mutations when rate is 1")
+	}
+}
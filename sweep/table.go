@@ -0,0 +1,73 @@
+package sweep
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func WriteCSV(w io.Writer, space Space, results []Result) error {
+	names := space.GetParamNames()
+	writer := csv.NewWriter(w)
+
+	header := append([]string{"run", "seed"}, names...)
+	header = append(
+		header,
+		"outcome",
+		"survival",
+		"organisms",
+		"species",
+		"richness",
+		"shannon",
+		"simpson",
+		"dominantDiet",
+		"duration",
+		"error",
+	)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		record := []string{
+			strconv.Itoa(result.Run.Index),
+			strconv.FormatInt(result.Run.Seed, 10),
+		}
+		for _, value := range result.Run.Params {
+			record = append(record, formatFloat(value))
+		}
+
+		errMessage := ""
+		if result.Err != nil {
+			errMessage = result.Err.Error()
+		}
+
+		summary := result.Summary
+		record = append(
+			record,
+			string(summary.Outcome),
+			strconv.Itoa(summary.Iterations),
+			strconv.Itoa(summary.Organisms),
+			strconv.Itoa(summary.Species),
+			strconv.Itoa(summary.Analytics.Diversity.Richness),
+			formatFloat(summary.Analytics.Diversity.Shannon),
+			formatFloat(summary.Analytics.Diversity.Simpson),
+			summary.Analytics.GetDominantDiet(),
+			fmt.Sprintf("%.3f", summary.Duration),
+			errMessage,
+		)
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,264 @@
+package timelapse
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dominik-zeglen/aquarium/render"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+type Config struct {
+	// Capture frame every this number of iterations
+	Every int
+	// Recorded iterations, recording lasts until recorder is closed if End is 0
+	Start int
+	End   int
+	// Keep only this number of the latest frames, 0 keeps all of them
+	Window int
+	// Delay between frames in hundredths of a second
+	Delay int
+	// GIF is written to this file once recording ends if not empty
+	Path   string
+	Render render.Options
+}
+
+const (
+	DefaultDelay = 10
+	DefaultEvery = 100
+)
+
+// Frames waiting to be rendered, sim step waits once queue is full
+const frameQueueSize = 16
+
+type Status string
+
+const (
+	StatusWaiting   Status = "waiting"
+	StatusRecording Status = "recording"
+	StatusDone      Status = "done"
+)
+
+func (c Config) withDefaults() (Config, error) {
+	if c.Every == 0 {
+		c.Every = DefaultEvery
+	}
+	if c.Delay == 0 {
+		c.Delay = DefaultDelay
+	}
+
+	if c.Every < 0 || c.Delay < 0 || c.Window < 0 || c.Start < 0 {
+		return c, fmt.Errorf("Timelapse settings must not be negative")
+	}
+	if c.End != 0 && c.End < c.Start {
+		return c, fmt.Errorf("Timelapse must not end before it starts")
+	}
+
+	return c, c.Render.Validate()
+}
+
+// Recorder captures rendered frames of sim it observes. Frames are rendered
+// from snapshots in the background, so sim lock is not held meanwhile.
+type Recorder struct {
+	ID           string
+	SimulationID string
+
+	config   Config
+	done     chan struct{}
+	onFinish func()
+
+	lock      sync.Mutex
+	captured  int
+	err       error
+	frames    []*image.Paletted
+	iteration int
+
+	queue       chan *sim.Snapshot
+	queueClosed bool
+	queueLock   sync.Mutex
+}
+
+func New(config Config) (*Recorder, error) {
+	return newRecorder(config, nil)
+}
+
+// onFinish is called once GIF is saved
+func newRecorder(config Config, onFinish func()) (*Recorder, error) {
+	config, err := config.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		config:   config,
+		done:     make(chan struct{}),
+		onFinish: onFinish,
+		queue:    make(chan *sim.Snapshot, frameQueueSize),
+	}
+	go r.renderFrames()
+
+	return r, nil
+}
+
+func (r *Recorder) renderFrames() {
+	defer close(r.done)
+
+	for snapshot := range r.queue {
+		img, err := render.Render(snapshot, r.config.Render)
+		if err != nil {
+			r.lock.Lock()
+			r.err = err
+			r.lock.Unlock()
+			continue
+		}
+
+		frame := image.NewPaletted(img.Bounds(), palette.Plan9)
+		draw.Draw(frame, frame.Bounds(), img, image.Point{}, draw.Src)
+
+		r.lock.Lock()
+		r.frames = append(r.frames, frame)
+		if r.config.Window > 0 && len(r.frames) > r.config.Window {
+			r.frames = r.frames[len(r.frames)-r.config.Window:]
+		}
+		r.captured++
+		r.iteration = snapshot.GetIteration()
+		r.lock.Unlock()
+	}
+
+	if r.config.Path != "" {
+		if err := r.save(); err != nil {
+			r.lock.Lock()
+			r.err = err
+			r.lock.Unlock()
+		}
+	}
+
+	if r.onFinish != nil {
+		r.onFinish()
+	}
+}
+
+func (r *Recorder) save() error {
+	f, err := os.Create(r.config.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := r.WriteGIF(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// finish stops capturing new frames, already queued ones are still rendered
+func (r *Recorder) finish() {
+	r.queueLock.Lock()
+	defer r.queueLock.Unlock()
+
+	if !r.queueClosed {
+		r.queueClosed = true
+		close(r.queue)
+	}
+}
+
+func (r *Recorder) OnStep(s *sim.Sim, data sim.IterationData) {
+	if data.Iteration < r.config.Start {
+		return
+	}
+
+	if (data.Iteration-r.config.Start)%r.config.Every == 0 {
+		r.queueLock.Lock()
+		if !r.queueClosed {
+			r.queue <- s.GetSnapshot()
+		}
+		r.queueLock.Unlock()
+	}
+
+	if r.config.End != 0 && data.Iteration >= r.config.End {
+		r.finish()
+	}
+}
+
+func (r *Recorder) OnEvent(sim.Event) {}
+
+// Close stops recording and waits until GIF is saved
+func (r *Recorder) Close() error {
+	r.finish()
+	<-r.done
+
+	return r.GetError()
+}
+
+// Done is closed once recording ends and GIF is saved
+func (r *Recorder) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Recorder) GetConfig() Config {
+	return r.config
+}
+
+func (r *Recorder) GetError() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.err
+}
+
+// GetFrameCount returns number of captured frames, including ones which no
+// longer fit in the window
+func (r *Recorder) GetFrameCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.captured
+}
+
+// GetIteration returns iteration of the last captured frame
+func (r *Recorder) GetIteration() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.iteration
+}
+
+func (r *Recorder) GetStatus() Status {
+	select {
+	case <-r.done:
+		return StatusDone
+	default:
+	}
+
+	if r.GetFrameCount() == 0 {
+		return StatusWaiting
+	}
+
+	return StatusRecording
+}
+
+// WriteGIF encodes frames captured so far
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	r.lock.Lock()
+	frames := make([]*image.Paletted, len(r.frames))
+	copy(frames, r.frames)
+	r.lock.Unlock()
+
+	if len(frames) == 0 {
+		return fmt.Errorf("Timelapse has no frames yet")
+	}
+
+	delays := make([]int, len(frames))
+	for frameIndex := range delays {
+		delays[frameIndex] = r.config.Delay
+	}
+
+	return gif.EncodeAll(w, &gif.GIF{Image: frames, Delay: delays})
+}
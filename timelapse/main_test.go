@@ -0,0 +1,218 @@
+package timelapse
+
+import (
+	"context"
+	"image/gif"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/render"
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func getTestSim() *sim.Sim {
+	s := &sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+
+	return s
+}
+
+func runSteps(s *sim.Sim, steps int) {
+	for it := 0; it < steps; it++ {
+		s.RunStep(context.TODO())
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Run("captures frames every n iterations in range", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		recorder, err := New(Config{
+			Every:  2,
+			Start:  3,
+			End:    9,
+			Render: render.Options{Width: 32},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Observe(recorder)
+
+		// When
+		runSteps(s, 12)
+		<-recorder.Done()
+
+		// Then
+		if recorder.GetFrameCount() != 4 {
+			t.Errorf("Expected %d, got %d", 4, recorder.GetFrameCount())
+		}
+		if recorder.GetIteration() != 9 {
+			t.Errorf("Expected %d, got %d", 9, recorder.GetIteration())
+		}
+		if recorder.GetStatus() != StatusDone {
+			t.Errorf("Expected %s, got %s", StatusDone, recorder.GetStatus())
+		}
+	})
+
+	t.Run("keeps rolling window of frames", func(t *testing.T) {
+		// Given
+		dir, err := ioutil.TempDir("", "timelapse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "timelapse.gif")
+
+		s := getTestSim()
+		recorder, err := New(Config{
+			Every:  1,
+			Window: 3,
+			Path:   path,
+			Render: render.Options{Width: 32},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Observe(recorder)
+
+		// When
+		runSteps(s, 8)
+		err = recorder.Close()
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		img, err := gif.DecodeAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(img.Image) != 3 {
+			t.Errorf("Expected %d, got %d", 3, len(img.Image))
+		}
+		if recorder.GetFrameCount() != 8 {
+			t.Errorf("Expected %d, got %d", 8, recorder.GetFrameCount())
+		}
+	})
+
+	t.Run("rejects range ending before start", func(t *testing.T) {
+		// When
+		_, err := New(Config{Start: 10, End: 5})
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("serves recorded gif", func(t *testing.T) {
+		// Given
+		s := getTestSim()
+		store := NewStore()
+		recorder, err := store.Record("default", s, Config{
+			Every:  1,
+			End:    3,
+			Render: render.Options{Width: 32},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		runSteps(s, 3)
+		<-recorder.Done()
+		response := httptest.NewRecorder()
+
+		// When
+		store.ServeHTTP(
+			response,
+			httptest.NewRequest("GET", "/timelapse?id="+recorder.ID, nil),
+		)
+
+		// Then
+		if response.Code != http.StatusOK {
+			t.Fatalf("Expected %d, got %d: %s", http.StatusOK, response.Code, response.Body)
+		}
+		img, err := gif.DecodeAll(response.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(img.Image) != 3 {
+			t.Errorf("Expected %d, got %d", 3, len(img.Image))
+		}
+	})
+
+	t.Run("saves recordings without end on close", func(t *testing.T) {
+		// Given
+		dir, err := ioutil.TempDir("", "timelapse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "timelapse.gif")
+
+		s := getTestSim()
+		store := NewStore()
+		recorder, err := store.Record("default", s, Config{
+			Every:  1,
+			Path:   path,
+			Render: render.Options{Width: 32},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		runSteps(s, 2)
+
+		// When
+		err = store.Close()
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		if recorder.GetStatus() != StatusDone {
+			t.Errorf("Expected %s, got %s", StatusDone, recorder.GetStatus())
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		img, err := gif.DecodeAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(img.Image) != 2 {
+			t.Errorf("Expected %d, got %d", 2, len(img.Image))
+		}
+	})
+
+	t.Run("returns 404 for unknown timelapse", func(t *testing.T) {
+		// Given
+		store := NewStore()
+		response := httptest.NewRecorder()
+
+		// When
+		store.ServeHTTP(response, httptest.NewRequest("GET", "/timelapse?id=1", nil))
+
+		// Then
+		if response.Code != http.StatusNotFound {
+			t.Errorf("Expected %d, got %d", http.StatusNotFound, response.Code)
+		}
+	})
+}
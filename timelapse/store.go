@@ -0,0 +1,106 @@
+package timelapse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+// Store keeps recorders started through the API, so their GIFs can be
+// downloaded once recording ends
+type Store struct {
+	lastID    int
+	lock      sync.Mutex
+	recorders []*Recorder
+}
+
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Record starts recording sim, observer is removed once recording ends
+func (st *Store) Record(
+	simulationID string,
+	s *sim.Sim,
+	config Config,
+) (*Recorder, error) {
+	var remove func()
+	r, err := newRecorder(config, func() {
+		s.Exec(context.Background(), func(*sim.Sim) error {
+			remove()
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	st.lock.Lock()
+	st.lastID++
+	r.ID = strconv.Itoa(st.lastID)
+	r.SimulationID = simulationID
+	st.recorders = append(st.recorders, r)
+	st.lock.Unlock()
+
+	s.Exec(context.Background(), func(s *sim.Sim) error {
+		remove = s.Observe(r)
+		return nil
+	})
+
+	return r, nil
+}
+
+func (st *Store) Get(id string) (*Recorder, error) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	for _, r := range st.recorders {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Timelapse %s not found", id)
+}
+
+func (st *Store) List() []*Recorder {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	recorders := make([]*Recorder, len(st.recorders))
+	copy(recorders, st.recorders)
+
+	return recorders
+}
+
+// Close stops all recorders and waits until their GIFs are saved, so ones
+// recording without end are saved as well. The first error is returned.
+func (st *Store) Close() error {
+	var err error
+	for _, r := range st.List() {
+		if closeErr := r.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("Timelapse %s: %s", r.ID, closeErr)
+		}
+	}
+
+	return err
+}
+
+// ServeHTTP sends GIF of recorder picked with id query parameter, frames
+// captured so far are sent if it's still recording
+func (st *Store) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder, err := st.Get(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/gif")
+	if err := recorder.WriteGIF(w); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+	}
+}
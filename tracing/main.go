@@ -3,6 +3,7 @@ package tracing
 import (
 	"fmt"
 	"io"
+	"net"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/uber/jaeger-client-go"
@@ -11,8 +12,15 @@ import (
 	"github.com/uber/jaeger-lib/metrics"
 )
 
+// Port of jaeger-agent, used if agent host does not contain one
+const defaultAgentPort = "6831"
+
 // InitJaeger must be called before using any tracing features
-func InitJaeger() (opentracing.Tracer, io.Closer) {
+func InitJaeger(agentHost string) (opentracing.Tracer, io.Closer) {
+	if _, _, err := net.SplitHostPort(agentHost); err != nil {
+		agentHost = net.JoinHostPort(agentHost, defaultAgentPort)
+	}
+
 	cfg := &jaegerConfig.Configuration{
 		Sampler: &jaegerConfig.SamplerConfig{
 			Type:  jaeger.SamplerTypeConst,
@@ -20,7 +28,8 @@ func InitJaeger() (opentracing.Tracer, io.Closer) {
 		},
 		ServiceName: "aquarium",
 		Reporter: &jaegerConfig.ReporterConfig{
-			LogSpans: false,
+			LocalAgentHostPort: agentHost,
+			LogSpans:           false,
 		},
 	}
 	jLogger := jaegerLog.StdLogger
@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+	"github.com/golang/geo/r2"
+)
+
+type Config struct {
+	// Wait this long between steps, 0 runs steps as fast as possible
+	Delay time.Duration
+	// Screen is redrawn at this interval
+	Refresh time.Duration
+	// Terminal size, it's read from terminal if zero
+	Width  int
+	Height int
+}
+
+const DefaultRefresh = 200 * time.Millisecond
+
+const (
+	maxZoom = 64
+	// Minimap needs at least this many rows and columns
+	minMapSize = 4
+	// Number of verbose lines visible below minimap
+	statsLines   = 6
+	speciesWidth = 30
+)
+
+const help = "space: pause, s: step, +/-: zoom, 0: reset, arrows/hjkl: pan, q: quit"
+
+// Diet flags of minimap cells
+const (
+	herbivore = 1 << uint(sim.Herbivore)
+	funghi    = 1 << uint(sim.Funghi)
+)
+
+// Background colours of minimap cells, indexed by diet flags
+var dietColors = map[int]string{
+	herbivore:          "\x1b[42m",
+	funghi:             "\x1b[45m",
+	herbivore | funghi: "\x1b[43m",
+}
+
+// Viewer runs sim and draws it on terminal, so it must not be run by anything
+// else meanwhile
+type Viewer struct {
+	config  Config
+	s       *sim.Sim
+	center  r2.Point
+	extinct bool
+	paused  bool
+	stats   []string
+	zoom    int
+}
+
+func New(s *sim.Sim, config Config) *Viewer {
+	if config.Refresh == 0 {
+		config.Refresh = DefaultRefresh
+	}
+
+	v := &Viewer{config: config, s: s}
+	v.reset()
+
+	return v
+}
+
+// reset shows the whole world
+func (v *Viewer) reset() {
+	env := v.s.GetSnapshot().GetEnvironment()
+	v.center = r2.Point{X: float64(env.GetWidth()) / 2, Y: float64(env.GetHeight()) / 2}
+	v.zoom = 1
+}
+
+func (v *Viewer) step(ctx context.Context) {
+	data := v.s.RunStep(ctx)
+
+	v.stats = append(v.stats, data.String())
+	if len(v.stats) > statsLines {
+		v.stats = v.stats[len(v.stats)-statsLines:]
+	}
+	v.extinct = v.s.GetCellCount() == 0
+}
+
+// getView returns visible area of the world, view is kept within its bounds
+func (v *Viewer) getView() (r2.Point, r2.Point) {
+	env := v.s.GetSnapshot().GetEnvironment()
+	world := r2.Point{X: float64(env.GetWidth()), Y: float64(env.GetHeight())}
+	half := world.Mul(.5 / float64(v.zoom))
+
+	v.center.X = math.Max(half.X, math.Min(world.X-half.X, v.center.X))
+	v.center.Y = math.Max(half.Y, math.Min(world.Y-half.Y, v.center.Y))
+
+	return v.center.Sub(half), v.center.Add(half)
+}
+
+// handleKey returns true if viewer should quit
+func (v *Viewer) handleKey(ctx context.Context, k key) bool {
+	start, end := v.getView()
+	pan := end.Sub(start).Mul(.25)
+
+	switch k {
+	case 'q', keyInterrupt:
+		return true
+	case ' ', 'p':
+		v.paused = !v.paused
+	case 's', 'n':
+		v.paused = true
+		if !v.extinct {
+			v.step(ctx)
+		}
+	case '+', '=':
+		if v.zoom < maxZoom {
+			v.zoom *= 2
+		}
+	case '-', '_':
+		if v.zoom > 1 {
+			v.zoom /= 2
+		}
+	case '0':
+		v.reset()
+	case keyUp, 'k':
+		v.center.Y -= pan.Y
+	case keyDown, 'j':
+		v.center.Y += pan.Y
+	case keyLeft, 'h':
+		v.center.X -= pan.X
+	case keyRight, 'l':
+		v.center.X += pan.X
+	}
+
+	return false
+}
+
+func (v *Viewer) getStatus() string {
+	if v.extinct {
+		return "extinct"
+	}
+	if v.paused {
+		return "paused"
+	}
+
+	return "running"
+}
+
+// getMap returns diet flags of alive organisms in each minimap cell, cells
+// are square in world units
+func (v *Viewer) getMap(s *sim.Snapshot, cols int, rows int) [][]int {
+	start, end := v.getView()
+	size := end.Sub(start)
+	cellSize := math.Max(size.X/float64(cols), size.Y/float64(rows))
+	cols = int(math.Min(float64(cols), math.Ceil(size.X/cellSize)))
+	rows = int(math.Min(float64(rows), math.Ceil(size.Y/cellSize)))
+
+	grid := make([][]int, rows)
+	for y := range grid {
+		grid[y] = make([]int, cols)
+	}
+
+	for _, organism := range s.GetOrganisms().GetArea(start, end).GetAlive() {
+		position := organism.GetPosition().Sub(start).Mul(1 / cellSize)
+		x, y := int(position.X), int(position.Y)
+		if x < 0 || y < 0 || x >= cols || y >= rows {
+			continue
+		}
+
+		for _, diet := range organism.GetSpecies().GetDiets() {
+			grid[y][x] |= 1 << uint(diet)
+		}
+	}
+
+	return grid
+}
+
+// getSpeciesTable returns alive species with the most organisms first
+func getSpeciesTable(s *sim.Snapshot, rows int) []string {
+	species := s.GetSpecies().GetAlive()
+	sort.SliceStable(species, func(i, j int) bool {
+		return species[i].GetCount() > species[j].GetCount()
+	})
+
+	lines := []string{fmt.Sprintf("%-20s %9s", "Species", "Organisms")}
+	for speciesIndex := 0; speciesIndex < len(species) && len(lines) < rows; speciesIndex++ {
+		name := species[speciesIndex].GetName()
+		if len(name) > 20 {
+			name = name[:20]
+		}
+		lines = append(lines, fmt.Sprintf("%-20s %9d", name, species[speciesIndex].GetCount()))
+	}
+
+	return lines
+}
+
+// draw writes whole screen at once, so it does not flicker
+func (v *Viewer) draw(w io.Writer) error {
+	s := v.s.GetSnapshot()
+	rows := v.config.Height - statsLines - 2
+	cols := (v.config.Width - speciesWidth - 2) / 2
+	if rows < minMapSize || cols < minMapSize {
+		return fmt.Errorf(
+			"Terminal must be at least %dx%d",
+			minMapSize*2+speciesWidth+2,
+			minMapSize+statsLines+2,
+		)
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("\x1b[H")
+	fmt.Fprintf(
+		buf,
+		"It: %d, organisms: %d, species: %d, zoom: %dx, %s\x1b[K\n",
+		s.GetIteration(),
+		s.GetAliveCount(),
+		len(s.GetSpecies().GetAlive()),
+		v.zoom,
+		v.getStatus(),
+	)
+
+	grid := v.getMap(s, cols, rows)
+	table := getSpeciesTable(s, rows)
+	for y := 0; y < rows; y++ {
+		x := 0
+		if y < len(grid) {
+			for _, diets := range grid[y] {
+				if color, ok := dietColors[diets]; ok {
+					buf.WriteString(color + "  \x1b[0m")
+				} else {
+					buf.WriteString(". ")
+				}
+			}
+			x = len(grid[y])
+		}
+
+		if y < len(table) {
+			fmt.Fprintf(buf, "%*s%s", (cols-x)*2+2, "", table[y])
+		}
+		buf.WriteString("\x1b[K\n")
+	}
+
+	for lineIndex := 0; lineIndex < statsLines; lineIndex++ {
+		if lineIndex < len(v.stats) {
+			buf.WriteString(v.stats[lineIndex])
+		}
+		buf.WriteString("\x1b[K\n")
+	}
+	buf.WriteString(help + "\x1b[K\x1b[J")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (v *Viewer) loop(ctx context.Context, keys <-chan key, w io.Writer) error {
+	refresh := time.NewTicker(v.config.Refresh)
+	defer refresh.Stop()
+
+	if err := v.draw(w); err != nil {
+		return err
+	}
+
+	for {
+		var next <-chan time.Time
+		if !v.paused && !v.extinct {
+			next = time.After(v.config.Delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case k, ok := <-keys:
+			if !ok || v.handleKey(ctx, k) {
+				return nil
+			}
+			if err := v.draw(w); err != nil {
+				return err
+			}
+		case <-refresh.C:
+			if err := v.draw(w); err != nil {
+				return err
+			}
+		case <-next:
+			v.step(ctx)
+		}
+	}
+}
+
+// Run shows sim on terminal until user quits, sim is paused once it dies out
+func Run(ctx context.Context, s *sim.Sim, config Config) error {
+	if config.Width == 0 || config.Height == 0 {
+		width, height, err := getSize()
+		if err != nil {
+			return err
+		}
+		config.Width, config.Height = width, height
+	}
+
+	restore, err := setRawMode()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	fmt.Print(enterScreen)
+	defer fmt.Print(leaveScreen)
+
+	keys := make(chan key)
+	go readKeys(os.Stdin, keys)
+
+	return New(s, config).loop(ctx, keys, os.Stdout)
+}
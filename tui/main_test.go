@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dominik-zeglen/aquarium/sim"
+)
+
+func getTestViewer() *Viewer {
+	s := &sim.Sim{}
+	s.Create(sim.SimConfig{
+		EnvDivisions:       4,
+		MaxCellsInOrganism: 25,
+		MaxOrganisms:       1e3,
+		Seed:               1,
+		StartCells:         10,
+	})
+
+	return New(s, Config{Width: 80, Height: 24})
+}
+
+func TestParseKeys(t *testing.T) {
+	// When
+	keys := parseKeys([]byte("q\x1b[A+\x1b"))
+
+	// Then
+	expected := []key{'q', keyUp, '+', 0x1b}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %d, got %d", len(expected), len(keys))
+	}
+	for keyIndex := range expected {
+		if keys[keyIndex] != expected[keyIndex] {
+			t.Errorf("Expected %d, got %d", expected[keyIndex], keys[keyIndex])
+		}
+	}
+}
+
+func TestViewer(t *testing.T) {
+	t.Run("keeps view within world", func(t *testing.T) {
+		// Given
+		v := getTestViewer()
+		v.handleKey(context.TODO(), '+')
+
+		// When
+		for it := 0; it < 10; it++ {
+			v.handleKey(context.TODO(), keyLeft)
+		}
+
+		// Then
+		start, end := v.getView()
+		width := float64(v.s.GetSnapshot().GetEnvironment().GetWidth())
+		if start.X != 0 {
+			t.Errorf("Expected %f, got %f", 0., start.X)
+		}
+		if end.X != width/2 {
+			t.Errorf("Expected %f, got %f", width/2, end.X)
+		}
+	})
+
+	t.Run("steps once and pauses", func(t *testing.T) {
+		// Given
+		v := getTestViewer()
+
+		// When
+		v.handleKey(context.TODO(), 's')
+
+		// Then
+		if !v.paused {
+			t.Error("Expected viewer to be paused")
+		}
+		if v.s.GetIteration() != 1 {
+			t.Errorf("Expected %d, got %d", 1, v.s.GetIteration())
+		}
+	})
+
+	t.Run("scrolls stats", func(t *testing.T) {
+		// Given
+		v := getTestViewer()
+
+		// When
+		for it := 0; it < statsLines+2; it++ {
+			v.step(context.TODO())
+		}
+
+		// Then
+		if len(v.stats) != statsLines {
+			t.Fatalf("Expected %d, got %d", statsLines, len(v.stats))
+		}
+		if !strings.HasPrefix(v.stats[statsLines-1], "It:      8") {
+			t.Errorf("Expected last line of iteration 8, got %s", v.stats[statsLines-1])
+		}
+	})
+
+	t.Run("draws species table", func(t *testing.T) {
+		// Given
+		v := getTestViewer()
+		v.step(context.TODO())
+		w := &bytes.Buffer{}
+
+		// When
+		err := v.draw(w)
+
+		// Then
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := v.s.GetSnapshot().GetSpecies().GetAlive()[0].GetName()
+		if !strings.Contains(w.String(), name) {
+			t.Errorf("Expected %s in species table", name)
+		}
+		if lines := strings.Count(w.String(), "\n") + 1; lines != 24 {
+			t.Errorf("Expected %d, got %d", 24, lines)
+		}
+	})
+
+	t.Run("rejects too small terminal", func(t *testing.T) {
+		// Given
+		v := getTestViewer()
+		v.config.Width = 20
+
+		// When
+		err := v.draw(&bytes.Buffer{})
+
+		// Then
+		if err == nil {
+			t.Error("Expected error")
+		}
+	})
+}
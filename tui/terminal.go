@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type key rune
+
+// Arrow keys do not map to single character
+const (
+	keyUp key = -1 - iota
+	keyDown
+	keyLeft
+	keyRight
+)
+
+// Sent instead of SIGINT, because terminal signals are disabled
+const keyInterrupt key = 3
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+var arrowKeys = map[byte]key{
+	'A': keyUp,
+	'B': keyDown,
+	'C': keyRight,
+	'D': keyLeft,
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	output, err := cmd.Output()
+
+	return strings.TrimSpace(string(output)), err
+}
+
+// setRawMode makes keys available without waiting for enter and returns
+// function restoring previous terminal settings
+func setRawMode() (func(), error) {
+	state, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("Could not read terminal settings: %s", err)
+	}
+
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1"); err != nil {
+		return nil, fmt.Errorf("Could not set terminal settings: %s", err)
+	}
+
+	return func() {
+		stty(state)
+	}, nil
+}
+
+// getSize returns number of terminal columns and rows
+func getSize() (int, int, error) {
+	size, err := stty("size")
+	if err != nil {
+		return 0, 0, fmt.Errorf("Could not read terminal size: %s", err)
+	}
+
+	var rows, cols int
+	if _, err := fmt.Sscan(size, &rows, &cols); err != nil {
+		return 0, 0, fmt.Errorf("Could not read terminal size: %s", err)
+	}
+
+	return cols, rows, nil
+}
+
+func parseKeys(input []byte) []key {
+	keys := []key{}
+
+	for index := 0; index < len(input); index++ {
+		if input[index] == 0x1b && index+2 < len(input) && input[index+1] == '[' {
+			if k, ok := arrowKeys[input[index+2]]; ok {
+				keys = append(keys, k)
+				index += 2
+				continue
+			}
+		}
+
+		keys = append(keys, key(input[index]))
+	}
+
+	return keys
+}
+
+// readKeys sends pressed keys until r is closed
+func readKeys(r io.Reader, keys chan<- key) {
+	defer close(keys)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		for _, k := range parseKeys(buf[:n]) {
+			keys <- k
+		}
+		if err != nil {
+			return
+		}
+	}
+}
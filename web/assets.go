@@ -0,0 +1,127 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func bindata_read(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _app_js = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x3a\xfd\x6f\xdb\x46\xb2\xbf\xeb\xaf\x98\x30\x46\x1f\xd9\x47\xd3\x6a\xf1\x5a\x04\x52\x95\x22\x4d\xd2\x07\xbf\x36\x2f\xbe\xba\x77\xd7\x83\x61\xc0\x2b\x72\x25\x6d\x4d\xed\xb2\xbb\xab\x48\x3a\x95\xff\xfb\x61\xf6\x8b\x4b\x4a\x8a\x93\x03\xce\x30\xe0\xfd\x98\x99\x9d\xef\x9d\x59\x3a\xd9\x28\x0a\x4a\x4b\x56\xea\x64\x3a\x1a\x5d\x5d\xc1\xdf\x18\xdd\x52\x09\x8d\xa8\x6b\x05\x7a\x45\xe1\xd5\xcd\x75\x0e\x4a\x00\xd3\xb0\x15\xf2\x51\xc1\x96\xe9\x95\xd8\x68\xf8\x3b\x9d\xdf\x8a\xf2\x91\x6a\x50\x9b\xa6\x11\x52\x03\xe3\xd0\x48\xb1\x63\x54\x15\x48\xea\x86\x28\x05\xdf\x2b\xb6\xde\xd4\x44\x33\xc1\x67\xdf\xb1\xea\x25\x68\x01\x5b\xa2\xcb\x15\x08\xbd\xa2\x12\xba\x6d\xd0\x2b\xc2\xa1\xa2\x0b\xb2\xa9\x35\x08\x4e\x81\xf0\x0a\xe9\x7c\x4f\x1a\xf6\x13\xdd\xcf\xbe\x7b\xa4\xfb\x97\xc0\x16\xc8\x12\x48\xfa\xc7\x86\x49\xaa\x80\x6c\xf4\x8a\x72\xcd\x4a\x73\x46\x31\x2a\x05\x57\x1a\x1a\x22\xc9\x5a\xc1\x0c\x38\xdd\xc2\x5f\x7f\xf9\xf9\x96\x12\x59\xae\x6e\xcc\x6a\xba\x65\xbc\x12\xdb\xa2\x16\x0e\x47\x99\xcd\x6c\xea\x70\x23\x96\x66\x8e\x50\xb1\xa4\x3a\x4d\xba\x8d\x24\x00\x5b\xde\x06\x80\x76\x11\x81\x1c\x14\xaa\xf3\x9a\x6b\x2a\x3f\x90\x1a\x66\xf0\xd5\x78\x3c\x9e\xa2\x68\xb7\xec\x9f\x14\xc4\x02\xd6\x8c\xb3\x35\x69\xa0\x61\x3b\x5a\xa3\x1e\xb7\x42\xd6\x15\x6c\x38\xd3\x2a\x07\xa6\xff\x4b\xc1\x82\xed\x68\x05\xf3\xbd\x37\x8a\xa3\x8c\x98\xef\x48\x73\x5b\x92\x9a\x5a\xca\x3d\xc2\x25\xe1\x1f\x88\x72\x74\x2b\x49\xb6\x1c\x16\x02\xb5\xce\x97\x35\xed\x1f\xeb\x59\xc5\xb1\xe1\x6b\x06\xdf\x06\x01\x2a\x46\xf5\x6b\x51\x0b\x89\x3a\x3d\x8c\x00\x16\x1b\xbe\x5c\xb1\x09\x24\xcf\x09\xf9\x86\x94\x2f\x92\x7c\x04\xb0\xa2\x72\xce\x3e\x08\x49\x71\xfd\x9b\xf1\x9c\x7e\x33\x36\xeb\x6b\xe4\x1d\xd7\xe8\xb7\xe5\x0b\xb3\xd6\x06\xca\x94\x7f\x60\x52\xf0\x35\xe5\xfa\x2f\x1b\x2a\xf7\x30\x83\x87\x11\xc0\x1f\x66\xfc\xb6\xdb\x4c\x2f\x3a\xf5\x4f\xe0\xfa\x4d\x66\xd8\x80\x18\x3f\x8d\x21\x22\x70\x0f\x0a\xb0\x65\x95\x5e\xb9\xf1\x8a\xb2\xe5\x4a\x9b\x49\x3b\x02\x68\x47\x0f\x81\x27\x34\xd7\x31\x33\x37\xa2\xae\xcf\x71\xc1\x34\x95\xe6\xac\xa7\x79\xe0\x9b\xf5\x9c\x4a\x37\x21\x35\xfb\x40\x5f\xd3\xba\x7e\x2d\x36\x5c\xbb\xc5\x72\x30\xdf\x12\xa5\x69\xc0\x07\xd0\x62\xc7\x4a\xa6\xf7\x6e\xa1\x75\x7f\x1b\x29\x4a\x49\xcd\xd1\x11\xb0\x6a\x68\xc9\xa8\x8a\x56\x00\x58\x15\x4d\xca\xe8\x24\xfc\x45\x53\x47\x53\x4e\xd6\x34\x4c\xfd\x51\x6d\x50\x1b\x78\x0f\x7c\x5a\xf0\x46\x28\x36\x60\x6e\x17\x46\x43\x61\x90\x0b\x75\xd2\x38\x44\x52\x72\x6c\x9c\x57\x92\x92\xf4\x02\xf7\x26\x66\x7c\xcd\x9b\x8d\x7e\x96\xc3\x19\x7b\x39\xad\xfc\xaf\x64\x55\x6a\x91\x0c\x6e\x0e\x4f\x4a\x71\xac\xcf\x48\x9b\x7d\x5d\x46\xaa\x8b\x15\x26\xe4\x92\x70\xa6\xd6\x3f\x33\xa5\x53\xb7\xbf\x60\xb5\xa6\x72\x02\x07\xeb\x11\x13\xd0\x72\x43\x73\x88\x58\x0b\x9a\x59\x30\xa9\xf4\x04\xbe\x1e\xbb\xf9\x19\x8e\xcd\x6e\xc7\x36\xad\x96\x3d\xa6\xb9\xa8\xe8\x79\x9f\x38\x61\xaa\xbe\xb9\x62\x93\x75\x92\x9d\x76\x91\xd8\x78\x5e\xf6\x63\x03\xbe\x77\x3b\xe9\x05\xab\x26\x70\xcd\x3f\x62\x3c\x4f\x24\x45\xc8\x0b\x56\x7d\x82\xd5\x82\x74\xa4\x0c\xca\x01\x20\x4b\x6f\x1f\xa3\x75\x37\x9e\x0b\xc9\x5f\x79\x2b\x56\x94\xe8\xd5\x6b\xb2\x51\x1e\xb2\x62\xb4\x0a\xbb\x6b\xa2\x94\x1f\x8a\x39\xab\xbb\xa0\xfc\x2c\x67\xff\xa8\x4f\xf5\xe2\xf1\xc8\xa5\x6c\xb6\x88\x51\x63\x51\x4e\x1b\x41\x69\xa2\xa9\xcb\xe3\xd6\xc3\xf8\xa6\xae\xf3\x51\x2f\x99\x76\x8b\x2e\xc0\x27\x70\x77\x9f\x8f\x3a\xed\x77\x00\x7e\xe5\xba\xf2\x6b\x5d\x7a\x77\x17\xd0\x0c\x2a\x51\x6e\x30\x8f\xe3\x0d\xf9\xb6\xa6\x38\xfc\x61\x7f\x5d\xa5\xc9\x9a\x34\xdd\x75\x5a\xea\x1d\xcc\x1c\x12\x42\xbe\x16\x5c\xd3\x9d\x4e\x93\xaf\x2b\x04\x1a\x11\xb5\xe7\x25\x5e\x3e\xc6\x8c\xf6\x96\x48\x11\x22\x87\x0f\x44\x32\x32\xaf\xa9\xb2\x4e\x6f\xe9\xad\x28\xa9\xa8\xbd\xb4\x20\x31\xc4\xb8\xbe\xfc\x75\xdf\xd0\x64\x02\x09\x69\x9a\xda\xd5\x0d\x57\xbf\x2b\xc1\x13\x68\xa7\x23\xc0\x0a\x23\xb5\x37\xb8\xf7\x1f\x47\xe5\x2e\xf9\xed\xf2\xd5\xcd\xf5\xe5\x4f\x74\x9f\xdc\xc3\x0c\x2c\x10\xa2\xb4\xa3\x70\xa2\xa4\xaa\x11\x5c\xa1\x7e\xc9\x96\x30\x0d\x0b\xaa\xcb\x95\xa9\x09\x92\xdc\xd1\x9b\x8b\x6a\x3f\x81\xff\xbb\x7d\xff\xff\x05\x16\x5f\x7c\xc9\x16\xfb\xd4\x5b\xd0\xc8\x34\x01\x23\x94\x5b\x0a\xa2\x4d\xe0\xfd\xfc\x77\x5a\xea\x82\x28\xc5\x96\x3c\x3d\x44\x9e\x0f\x6d\xac\x03\xb4\x0b\x40\x9b\xe5\xb1\x00\x76\xb2\xa6\x7a\x25\xaa\x09\x24\x37\xef\x6f\x7f\x35\xf7\x73\x9b\x79\xb9\x9f\x79\xf6\x0b\xf1\xe8\xa5\xd7\x2b\x29\xb6\xa6\x90\x7a\x2b\xa5\x90\xe9\xc3\xc5\x21\x40\xa1\x2b\x6d\x54\x0b\x17\x07\x2b\x6c\xd8\x40\xf6\xd3\xac\x7d\xc8\x8e\xd5\x83\xe5\xdd\x0c\x06\xf0\xa8\xff\x34\xb0\x61\xa1\x0a\x8a\xe7\x29\xf8\xe2\x0b\xe8\x2d\x14\x35\xe5\x4b\xbd\x82\x97\x30\x3e\xc7\x63\x1f\x7e\x4d\x9a\x34\x35\xb8\x19\xcc\x5e\x82\x19\x15\x6b\xaa\x14\x59\xd2\xac\xf8\x5d\x30\x9e\x26\x39\x24\x59\xc7\xac\xa4\x7a\x23\xb9\x3f\xb6\x22\x9a\x4c\x47\xed\x68\x14\x1c\x4f\xad\xc4\xd6\x9e\xe4\xc8\x76\x2e\x47\xad\x73\x7f\xcc\xe5\x0d\x0e\xfa\x33\x78\xe8\x62\xc5\xaa\x8a\x72\x98\xc1\x33\xb3\x19\x6f\xa1\x2a\x9d\xeb\xc2\xcc\x32\x0f\xdf\xf7\x85\x80\x09\x24\x49\x9f\xc3\x25\xd5\x6f\x7c\xd1\x96\x62\x0e\x71\x71\x81\x56\x36\xd3\x4e\x8b\x5f\x79\x2d\x3a\xa9\xbb\x62\xaf\x30\x95\xdb\x50\x2b\xdd\xfe\x1d\x0e\xd5\xdd\xf8\xfe\x1e\xfe\xfc\xf3\x04\x5e\x4f\x65\x54\xff\xc8\x68\x5d\xa5\x0d\x91\x94\xeb\x1c\x16\x38\x43\x9f\xad\x37\xd4\x72\x60\x77\x0a\x13\x01\xb7\xb4\xa6\xa5\x46\x77\xbb\x43\xf5\x5f\x1a\xe8\x59\x72\x71\x30\x83\x36\xb9\x7f\xc8\x06\x9a\x31\x84\xfa\x67\x62\xc5\xfb\x8e\x34\xa9\xb3\x8f\xde\x15\x0b\x56\xd7\xb7\x7a\x6f\x8a\xe5\xe4\xf9\xf8\xc5\x98\x7c\xf5\x22\x99\x46\x9b\xbf\xd0\x52\xa7\xe3\x1c\xc6\xb9\x4f\x43\xa6\x68\x0c\x33\x5b\x36\x62\x2e\x02\x53\x4a\xa7\x51\xd9\x8c\xa5\x3c\x46\x04\x2d\x5c\xce\xf4\x8a\x1d\x9e\xdc\x33\x8e\x41\x2d\x50\x79\x2a\x9b\xf6\xc0\x0d\x2f\x2e\x07\x58\x28\x7f\xad\x14\x3b\xf8\xb2\x2b\xd5\xf3\xd3\x30\xfb\xb3\x30\x27\x17\x0c\x91\x61\xbc\x2a\xd7\x58\x04\x28\xb8\xf2\x17\x82\xe9\x39\xbc\xea\x94\x96\xe2\x91\x76\x9a\x5d\x98\x9f\xc4\x07\xb4\xd5\x0a\x5e\x36\xb1\x4a\x2c\x52\x2c\x65\x07\x57\x28\x4d\xa4\x36\x72\x1a\x1e\xf2\x73\x10\xfb\x21\x44\xcc\xde\xa7\xec\xc5\x72\xc7\xdc\xfa\x6b\x0d\xf3\x4f\x7f\xa5\x30\x77\x6c\x90\xc4\x38\xc0\x01\x76\x39\xec\xa1\x85\xd9\x10\xd8\xdb\xa3\xb3\xed\x9c\x2e\x19\xbf\x21\x7a\x95\x46\x06\x27\xb2\x4c\x3b\x69\xa1\x13\x2b\x32\x19\x7a\xe5\xd7\xf0\x25\xbc\x23\x7a\x55\xdc\x5c\x47\xd8\x56\x95\xa9\x13\x63\x18\x03\xd7\xa1\x3d\x09\x8d\xca\x67\xa6\xac\x80\x67\xd3\x96\x73\x0e\x57\xb7\xcc\xba\xfe\xa7\x88\xba\x90\xc2\xd5\x35\xe8\xcb\x9a\x4a\x6b\xe3\xd4\x2d\x9a\x4c\xec\x01\x4c\xdd\x8c\xf9\x7c\x64\x2c\x31\x82\x2e\x5b\x38\xde\x72\x48\x6c\xe3\x94\xe4\xd1\x61\x76\x29\x9b\x9e\x46\xe8\x37\x57\x3d\xc4\xfe\xd6\x39\x02\xe5\x49\xdc\xf2\x29\x34\xdf\x9f\xf5\xb0\x4c\x13\x57\xf8\xad\x42\x8b\x1f\x31\xb5\xa6\xff\x93\x9d\xa3\xe2\x74\x93\xe4\x5e\xcd\x2e\x61\x5b\xfd\x58\x03\x68\xac\x24\x62\xb3\xf5\x53\x67\xf2\xdc\xa1\x5a\x40\x6b\x3a\x33\x1c\xa4\x4e\xbc\x3c\x42\x19\x6a\xcc\x54\x28\x21\x75\x9a\x92\x1c\xe6\xc6\x52\x73\x67\xa3\x4b\x20\x76\x94\x59\xb0\x85\x90\x6f\x49\xb9\x4a\x7b\x66\xf5\xa5\x8c\xbb\xf2\xc5\x16\x66\xee\x58\xc6\x15\x95\xfa\x17\xb1\xf5\x9e\x0f\x20\xc5\xd6\x2d\x63\x1f\x9c\x0e\xb3\xba\xa3\x5b\x60\xe5\xfb\x99\x28\x98\x52\xa3\xdb\xfd\x33\xb1\x8d\x94\x53\x57\x46\x1d\x5f\x2b\xd8\x64\xa6\x78\x33\xc5\x81\xa4\x68\xe9\xde\x8d\xce\x06\x12\x66\xae\x38\x86\x6a\xa6\x8c\x98\x16\x73\x68\xc0\x4d\x9d\x38\xff\xb0\xdb\xa1\x4c\x58\x90\x5a\x51\xdc\x41\xfc\x81\x04\x68\xcd\x40\xff\x80\xe9\x48\xea\x28\x31\x21\x07\x3d\x9f\x33\x32\xba\x13\x6c\xa2\x4c\xe6\x62\xc3\x2b\x65\x8a\x43\x80\x87\x8b\x83\x4b\xc6\x6d\x0e\x7e\xbc\x6f\xe1\x32\x4c\x76\xf0\xdf\xbd\xd4\x1a\xc3\x0d\xb7\x1e\x42\x8c\x0f\x93\xc8\xa1\x9d\xf6\x2f\x55\x17\x0c\x78\xad\xa2\xa6\x7d\x3e\xc1\xae\xdd\x27\xdf\x08\x5a\x21\x9c\x43\xf1\xa0\x1e\x2c\x38\xf7\x9d\x2a\x58\x85\xc5\xbb\x72\xa6\x75\x69\x7f\x48\xc7\xd5\xd9\xa6\xa6\x50\xc1\xb9\x3d\x39\xcb\x1e\xd3\x74\x1d\x9b\xda\xbc\xbe\x50\x67\xed\x34\xa9\x99\xf7\x39\x04\x1c\xd8\x08\x75\x6a\x7c\xba\x85\x14\x87\xc6\xdb\xda\xd0\x4a\xa9\xec\xc1\xa2\x1a\xf3\x92\xa6\xa1\xbc\x7a\xbd\x62\x75\x95\x22\x2d\x97\xe5\xfb\x6c\x1f\x6c\x4f\xdf\x06\x6d\x79\x5a\xf8\xce\x50\x98\xfe\xff\xdf\x64\xdf\x3b\x2a\x7f\xfc\x08\xb8\x73\x6a\xe4\x98\x3f\x0e\x85\xf5\xcd\x3d\x3c\xbf\x38\x20\x97\x05\xab\xda\x20\x20\x7f\x2c\x48\x55\xbd\xfd\x40\xb9\x46\x56\x29\xa7\x32\x4d\xca\x9a\x95\x8f\x49\x0e\xa9\x49\x28\x8c\xa3\x05\x74\xea\x70\x33\x77\x14\x4a\xd0\x53\x0e\x9e\x9d\x3d\xad\xb8\x61\x2c\x87\xb7\x87\xcf\x8c\x65\xaf\xe1\x4f\x8f\xe7\xca\xc5\x73\xff\x35\xe4\xa8\x66\x38\x19\xf2\xcf\xe2\xdd\x33\x61\xef\xba\x38\x0f\xe9\x0d\x6e\x0b\xfb\x41\x69\x67\x0a\x69\x8c\xbb\x3b\xa3\xb0\xbb\xe4\xfa\x4d\x92\x07\x9e\x30\x4c\x72\xb7\x71\x1b\xae\xa2\xb0\xeb\x22\xc2\xb8\x70\x80\xc3\x86\xe3\x14\xd0\x20\x11\x07\xf8\x57\x4b\x1a\x83\x93\x25\x0d\x5b\x3f\x08\xc9\x81\xf4\xa8\xd9\x77\x99\x00\xf1\x8e\xa8\x1e\x47\xf8\x16\xd3\x6d\xba\xd7\x98\x1e\x80\x5b\xf3\x40\x2e\x31\x24\x78\x0b\xf8\x54\x67\x92\x5d\xc0\xc0\x9b\x3e\x54\x2f\x29\xce\x8c\x37\xe2\xc0\x95\x80\xee\x4e\x6e\xe1\x0a\x8e\xf0\xfc\x96\x81\x7c\xb0\xf4\x03\x83\xaf\x8c\x71\x63\xf6\xec\xcb\xd4\x90\xb9\x1b\x57\x3c\x9e\xe4\x2f\x54\xfa\xbb\x50\x4f\x8c\xb3\x36\x87\x53\x20\x5d\xc9\x31\xce\xda\x98\x9b\xfb\x23\xaf\xe9\x97\xb7\xd6\x4d\x8a\x66\xa3\x56\xe9\x5d\xf2\x86\xd1\x6a\x60\x17\xfb\x22\x76\x9f\xc3\x5d\x62\x1e\xca\x7a\x7b\xe1\xf9\xec\xfe\x64\xd2\xba\xab\xc9\x9c\xd6\xae\x09\xbc\xc7\xd4\x65\x8f\xf3\x87\xbb\xaf\x0f\xfa\x23\x89\xa7\xd2\xfd\x3c\x55\x55\x1f\x03\xae\x3c\x70\x35\x0c\x1f\xc3\x89\xdb\xab\x4e\xb7\x96\x83\xbc\x92\x56\x3a\x87\xaa\xea\x92\xca\xe0\x75\xca\x67\x2d\x7f\x5b\xf5\x63\xfc\x1a\xf9\x64\x55\x97\x0d\x30\x69\x87\x57\x23\x53\x07\xa4\x1e\xd6\x3c\x97\xe6\x70\x00\x56\x41\x9b\x4d\x8f\x68\xc1\xac\x9f\xf2\x11\xa2\x9f\xd8\xfc\x8a\xe9\x86\xa7\x27\x98\x55\xa6\x68\x34\x55\x0d\xc5\x54\x1c\xa7\x42\x49\x4b\xdd\x7b\x96\xfb\x01\xcb\x03\xc6\x97\xaf\x6b\x46\xb9\x36\x0d\x5c\x94\xd6\x76\x30\x83\xd4\x52\x29\x4a\x03\xf1\x1b\x5c\x1a\x22\x45\x4d\x17\x3a\x83\x2b\x3b\x31\x8d\x75\x06\x5f\x7a\xc2\x66\xde\x91\xd9\x1f\x91\xf9\x87\x27\xa3\x45\x13\xa8\xb8\x86\xbc\x23\x63\x17\x3a\x3a\x8d\x60\xc6\x88\x28\x0f\xc0\x6e\x02\xe9\x0e\xae\xba\xa6\x0a\x19\x88\x0b\x14\x8c\x09\x80\xfd\x04\xd2\xfd\x53\x60\x6d\x77\x88\x29\x8a\xe2\x43\x4c\x8b\xb6\xa8\x05\x36\xf8\xc8\x40\xb1\x1b\x74\xce\x67\xcf\x3d\xc2\xdc\x7f\x02\x26\xbe\xb8\x7a\xaf\xc0\x02\x2f\xb0\x62\x18\xcb\xdd\xa7\xb2\x0a\xbf\x30\xec\x26\x70\xba\x6a\xcb\x51\xea\xd3\x55\x1b\xb4\x96\x84\xe9\x48\x27\x67\x4e\xd7\x72\x0f\x87\x38\x12\x8f\x1d\x3a\x7c\xbc\x41\x67\xc6\xc9\x24\xe6\xb9\xf5\xb1\xd9\xab\xaf\x0d\x6d\x80\xab\xab\xf0\x8d\x00\xca\x5a\x28\xaa\x34\x7e\x4c\x36\x85\x02\xad\x9c\x95\x99\xf2\x51\x47\x2b\x90\xe8\x07\x18\x4f\xfb\x88\x29\x4e\x89\x44\xd4\xd9\xb9\x22\xc9\xe5\x59\xfb\x68\xe8\x8b\x2a\x93\xf7\x71\x98\xf9\x6d\xd3\x1f\xb9\x09\x40\x68\x94\xc2\x0a\xd8\x26\x7d\xb5\x6f\x84\x4e\x49\x97\x87\x77\x70\x09\xce\x21\x72\x88\xd6\xf7\x61\x7d\x9f\xc1\xe5\x69\x32\xf3\x33\x64\xe6\xa7\xc9\x38\x22\xd9\xdd\xf8\xde\xd5\x4b\x0b\x48\x9d\xfc\x3e\xc1\x82\x33\x8f\xcf\x55\x6e\x1b\x8b\xac\xae\x40\xee\xa5\x0e\x9c\x76\xcf\x9f\xf8\xde\x6f\x16\x5b\x28\xcd\x67\xfd\xf8\x41\x34\x06\xb4\xcb\xe7\x52\x25\x7e\x71\x75\xaf\x74\x9d\x17\x99\x4b\xc9\xba\x47\xf4\x61\xa2\xe3\xfc\xac\x97\x45\xd0\xc6\xd9\x1c\xd7\x00\x47\xc4\xbc\x1b\x44\x4b\x1e\x36\x4e\x4a\x30\xb3\x66\x28\x29\xab\xd3\x23\x22\x0e\xe6\x38\x48\x43\xf2\x18\xd0\xb4\x19\xea\x09\xa2\x0e\xe8\x49\xaa\xed\x28\xf2\xee\x13\xca\x08\x1f\xb3\xbd\xed\xe2\x07\x4a\x2f\xbf\x9b\x76\xe1\xd7\xbd\x18\x21\xc9\x22\xbc\x63\xf8\x68\x3c\x7e\x26\xbb\xae\xe0\xd9\x6c\x66\x3e\x00\x9d\x73\xae\x21\xc2\x7f\xd0\xc5\xf0\x8a\xa4\xfa\x57\xb6\xa6\x62\xa3\x8d\x0e\xf2\xde\x7f\x61\xd8\x3b\xd0\xd9\xe3\x7c\xfb\xd1\xdd\x8a\xd9\x74\xd4\x88\xba\x4e\xb3\xe9\xe8\x5f\x03\x00\xc9\xb2\xf4\x11\x36\x23\x00\x00")
+
+func app_js() ([]byte, error) {
+	return bindata_read(
+		_app_js,
+		"app.js",
+	)
+}
+
+var _index_html = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x54\x4f\x6f\xdb\x3e\x0c\xbd\xf7\x53\xe8\xa7\xf3\x2f\x15\xda\xd3\x0e\xb4\x81\x2e\xdd\xb0\x5d\xd6\x01\x2b\x30\xec\xa8\x48\x4c\xc4\x55\x96\x3d\x89\x4e\xda\x6f\x3f\xc8\xff\x62\xa7\x59\xda\xe4\x60\x93\x7c\x7c\xa2\xf9\x48\xc1\x7f\xf7\x0f\xeb\xc7\x5f\xdf\x3f\x09\xc7\x95\x2f\xaf\x20\x3f\x84\xd7\x61\x57\x48\x0c\xb2\xbc\x12\x02\x1c\x6a\x9b\x5f\x84\x80\x0a\x59\x0b\xe3\x74\x4c\xc8\x85\x6c\x79\xbb\xfa\x20\x85\x9a\x07\x83\xae\xb0\x90\x7b\xc2\x43\x53\x47\x96\xc2\xd4\x81\x31\x70\x21\x0f\x64\xd9\x15\x16\xf7\x64\x70\xd5\x19\xff\x0b\x0a\xc4\xa4\xfd\x2a\x19\xed\xb1\xb8\x39\x52\x31\xb1\xc7\xf2\xee\x4f\xab\x23\xb5\x15\xa8\xde\xee\x63\x9e\xc2\x93\x88\xe8\x0b\x99\xf8\xc5\x63\x72\x88\x2c\x85\x8b\xb8\x1d\x3c\xd7\x26\xa5\x81\x0a\xd4\x58\x3c\x6c\x6a\xfb\x32\x30\x64\x1f\xc6\xde\xc8\xe6\xcd\xec\x24\x77\x33\xf9\xad\x17\x64\x0b\x49\x8c\x51\x33\xd5\x7d\x37\xc6\x18\x97\x5f\x47\x3f\x28\xcb\xf3\x90\x15\x56\xb3\x5e\x6d\x09\xbd\x2d\x64\x68\xab\x0d\x46\x59\xae\x40\x59\xbb\x64\xb8\xf3\xb4\x47\x61\xd0\xfb\x74\x99\x43\x67\xe0\x1a\xbd\x5f\xd7\x6d\xe0\xb3\x5c\xeb\xb7\x59\xcc\x45\x82\xc7\xfa\x99\x0c\xf1\xcb\x65\x0e\x1e\x50\x67\x29\x7e\x34\x68\x08\xdf\xa8\x22\xf5\xa0\x13\x02\x50\xd6\x4f\xef\x4d\xd7\x77\x8c\xb1\x8e\x52\x38\xb2\x16\x43\x09\xaa\x19\xd4\x53\x73\xf9\xa0\xd2\x14\xa6\xc4\x84\x26\x0b\xd2\xa5\x1f\xea\xe8\xed\x5c\x32\x77\x5b\xfe\xcc\x3e\x50\xee\x76\xe6\x36\x3a\xec\x75\xea\x52\x2a\xdd\xc8\x12\x54\xef\x99\x41\x5a\x2f\x8c\xd7\x29\x15\xd2\xe3\x0e\xc3\x9c\xb5\x9b\xc7\x12\x52\xa3\xc3\x88\x49\x07\xcd\xc6\x09\x87\x71\x43\xfb\x3a\x62\xa6\xcc\xf1\xf2\xcb\xe8\x01\xe5\xe9\x3d\x14\xdb\x36\xec\x1c\x4d\xf9\x9f\x3b\xf3\xbd\xc9\x15\x3d\xa3\x9d\x72\x3f\xd6\xec\x96\x99\xa0\xda\xa9\xe3\x5d\xcf\x87\x74\x47\x79\xc2\xd6\x9e\xcc\x93\x60\x87\xa2\xd2\x8d\xe0\x5a\x50\xc8\xc2\xb1\x18\xe4\x13\x3a\x58\x51\xc7\x9d\x0e\x94\xaa\x94\x81\x11\xaf\x27\x8d\x32\xfb\xa0\xc5\x59\x6d\xa6\x19\x18\x82\x79\x09\x6f\x8f\xe3\xb3\xd0\x87\xf5\x66\x5c\xfd\xd1\x73\xbc\x90\xc6\x1f\xf0\xb4\xce\xe3\x1f\xd8\x95\xdf\x74\x85\xa0\xd8\x9d\x8b\xdd\x13\xf2\xbf\x62\x0f\xe3\x87\xbd\x06\x80\x5a\x1e\x05\xea\x55\x39\xc0\xdd\x5d\x03\x8a\x8f\x77\xce\x00\x9d\x7f\xcb\xe5\x16\xe9\x88\x7a\x1a\xfe\x65\x9b\xd0\xa3\x61\xb4\x22\x43\x4e\x9a\xd5\x2c\x56\x6d\x53\xb7\xc1\x26\x79\xdc\x9d\x61\x9a\xcb\xb9\xf6\x97\xcb\x18\x25\x3e\x5f\xca\xd8\xa7\x93\x2a\xac\x2f\x17\xfb\xbc\x3c\x01\xd4\x71\x67\x21\x99\x48\x0d\x8b\x14\x4d\x21\x75\xd3\x5c\xff\xee\xca\xed\xbd\x19\x02\xaa\xef\x21\x28\xc7\x95\x2f\xaf\xfe\x0e\x00\xa6\xea\x47\x6e\xb0\x06\x00\x00")
+
+func index_html() ([]byte, error) {
+	return bindata_read(
+		_index_html,
+		"index.html",
+	)
+}
+
+var _style_css = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x93\x71\x6f\xa3\x3c\x0c\xc6\xff\xe7\x53\x58\xaa\x5e\xe9\x9d\xb4\x4c\xc0\xb5\x1c\x97\x7e\x1a\x93\x18\xb0\x16\x12\x94\xa4\x2b\xbd\x6a\xdf\xfd\x14\x58\x3b\xda\x6e\xd3\x24\xd6\x24\x8f\xed\x5f\xfc\x38\x8d\xd3\x27\x38\x67\x00\x0d\xaa\xd7\xce\xbb\x83\xd5\x12\x36\x79\x9d\x63\x51\xef\x33\x00\xe5\x8c\xf3\x12\x36\x54\xa5\xbf\xb4\xd3\x3a\x1b\x45\x8b\x03\x9b\x93\x84\x80\x36\x88\x40\x9e\xdb\xeb\x51\xe0\xbf\x24\xa1\xd8\x8e\x53\xda\x1a\xd0\x77\x6c\x25\xe4\xfb\xec\x3d\xcb\x7a\x42\x4d\x7e\xae\x87\x86\x3b\x2b\x38\xd2\x10\x24\x28\xb2\x91\x7c\xd2\x37\xce\x6b\xf2\xa2\x71\x31\xba\x41\x42\x31\x4e\x10\x9c\x61\x0d\x9b\xb2\xde\x56\x15\x25\x8d\xe6\x30\x1a\x3c\x49\x68\x0d\xcd\x45\xd2\x7f\x71\xf4\x38\x4a\x48\xdf\xb4\xd5\xa5\x45\xf9\x01\x31\xa2\xd6\x6c\x3b\x09\xf5\x38\x41\x51\xa5\xcd\x04\x53\xc0\xf9\x16\xba\xcc\xbf\x84\x2e\xef\x75\x45\x75\xa7\x83\x3c\xa5\x9e\xb3\x6a\x03\xe7\x35\x63\xe7\x59\x7f\x02\xa5\xfa\x65\x52\xc2\x7c\x20\x22\x0d\xa3\xc1\x48\x42\x39\x73\x18\x6c\x90\x80\x87\xe8\xe6\xcf\x17\x20\x4b\xf7\xee\x2b\x7c\xdb\x85\x44\x13\xe1\xbc\x72\xb1\x56\x7f\x2a\xc4\x85\x53\xc3\xf9\xa1\xc2\x80\x6c\x7f\xc0\x2f\xaa\x1f\xd1\x07\xb6\x03\x4e\xff\xe7\xcf\x50\xb6\xfe\x69\xb5\x2c\x5a\xff\x74\xe3\xc3\xd5\x83\x40\x2a\xb2\xb3\x8f\x13\x58\xe4\xc5\xb6\xc4\xd5\x44\x78\xd4\x7c\x08\x12\xee\x2d\x2d\xca\x8f\x54\x9b\xa3\xf3\x66\xb9\xd4\xcc\xe7\xdd\x51\x42\x18\xd1\xc2\xaf\xb9\x94\x42\xfb\x86\x61\x3e\x57\x07\x1f\x52\x3f\x94\x77\x21\xf4\xc8\xf3\xe4\xf1\x80\x1d\x09\x4f\x56\x93\x9f\x21\x47\x9e\x28\x79\x33\xdb\x37\xe0\x24\x8e\xac\x63\x2f\xa1\xc8\xf3\xff\xe6\x8c\x11\x1b\x43\x70\xfe\x64\x54\xce\x18\x1c\x03\x49\xb8\xfc\x4a\xa1\x0f\x61\xfd\x73\x16\x17\xd0\xeb\x2d\xca\x71\xba\xdc\x2c\xd2\x14\xc5\xfc\x3a\x24\x18\x6a\xe3\x52\x4a\x4b\x83\x21\x0a\xd5\xb3\xd1\xcf\x59\xec\x57\x4b\x38\xdf\x45\x79\xee\xfa\x25\xec\x60\xee\x4d\xbe\xd6\x14\x29\xf7\xca\x89\x17\x43\x1d\x59\xfd\xf5\x68\xdd\xb8\x6f\x38\x44\x11\xe2\xc9\x90\x04\xeb\x2c\xdd\xd8\xb1\x4c\xea\x4b\x38\x62\x54\xfd\x6d\x32\xb6\x86\x2d\x89\xc6\x38\xf5\x9a\x62\x7a\x4a\x9c\xa9\x9f\xeb\xd7\x24\x66\xfa\xab\xcf\xd7\xe6\x5d\x30\x7b\xf2\x0d\xbf\x39\x4f\x8f\x33\xb3\xcb\x1b\xda\x7d\x00\xb4\x07\xdb\xf5\xfc\xa8\x41\xdc\xa1\xaa\x17\xcd\xc0\x13\xe9\x47\x09\x55\xaa\xbe\xa4\xe9\xd9\x7e\xfb\x82\x36\xe4\xbd\xf3\x37\xa7\x54\xed\x70\x5b\x3d\xbe\x5c\xbc\x51\xfd\xae\x11\xdb\x7c\xbf\x1a\xc4\xd1\xb1\x8d\xe4\xf7\xd9\x7b\xf6\x6f\x00\xfe\xdc\xec\x01\x91\x05\x00\x00")
+
+func style_css() ([]byte, error) {
+	return bindata_read(
+		_style_css,
+		"style.css",
+	)
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		return f()
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() ([]byte, error){
+	"app.js": app_js,
+	"index.html": index_html,
+	"style.css": style_css,
+}
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for name := range node.Children {
+		rv = append(rv, name)
+	}
+	return rv, nil
+}
+
+type _bintree_t struct {
+	Func func() ([]byte, error)
+	Children map[string]*_bintree_t
+}
+var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
+	"app.js": &_bintree_t{app_js, map[string]*_bintree_t{
+	}},
+	"index.html": &_bintree_t{index_html, map[string]*_bintree_t{
+	}},
+	"style.css": &_bintree_t{style_css, map[string]*_bintree_t{
+	}},
+}}
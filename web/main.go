@@ -0,0 +1,29 @@
+package web
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Handler serves viewer embedded from web/static, run "make web" after
+// changing it
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if name == "" {
+			name = "index.html"
+		}
+
+		data, err := Asset(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(data)
+	})
+}
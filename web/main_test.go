@@ -0,0 +1,58 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	t.Run("serves index at root", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+
+		// When
+		Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+
+		// Then
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected %d, got %d", http.StatusOK, recorder.Code)
+		}
+		if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/html") {
+			t.Errorf("Expected html, got %s", recorder.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(recorder.Body.String(), "app.js") {
+			t.Error("Expected index to load app.js")
+		}
+	})
+
+	t.Run("serves script", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+
+		// When
+		Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/app.js", nil))
+
+		// Then
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected %d, got %d", http.StatusOK, recorder.Code)
+		}
+		if !strings.Contains(recorder.Body.String(), "miniMap") {
+			t.Error("Expected script to query miniMap")
+		}
+	})
+
+	t.Run("returns 404 for unknown file", func(t *testing.T) {
+		// Given
+		recorder := httptest.NewRecorder()
+
+		// When
+		Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/../main.go", nil))
+
+		// Then
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
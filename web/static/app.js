@@ -0,0 +1,376 @@
+"use strict";
+
+// Viewer polls the API, so it works without WebSocket support in proxies.
+// Pass ?simulation=<id> to watch other simulation than default one and
+// ?apiKey=<key> if API requires authentication.
+const params = new URLSearchParams(window.location.search);
+const simulation = params.get("simulation");
+const apiKey = params.get("apiKey");
+
+const pollInterval = 1000;
+// Size of minimap pixel in world units, it's fixed by the API
+const miniMapScale = 100;
+// Size of canvas pixel drawn for single minimap pixel
+const pixelSize = 6;
+
+const dietColors = {
+  funghi: "#aa5ac8",
+  herbivore: "#50be50",
+  mixed: "#e6c850",
+};
+
+const environmentQuery = `
+  query Environment($simulation: ID) {
+    environment(simulation: $simulation) {
+      width
+      height
+    }
+  }
+`;
+
+const pollQuery = `
+  query Poll($simulation: ID) {
+    iteration(simulation: $simulation) {
+      number
+      aliveCellCount
+      cellCount
+      waste {
+        toxicity
+      }
+      procreation {
+        species {
+          id
+          count
+          diet
+          name
+        }
+      }
+    }
+    miniMap(simulation: $simulation) {
+      position {
+        x
+        y
+      }
+      diets
+    }
+  }
+`;
+
+const areaQuery = `
+  query Area($area: AreaInput!, $simulation: ID) {
+    speciesGrid(area: $area, simulation: $simulation) {
+      species {
+        id
+        count
+        name
+      }
+    }
+    organismList(
+      filter: { alive: true, area: $area }
+      first: 20
+      simulation: $simulation
+    ) {
+      edges {
+        node {
+          id
+          position {
+            x
+            y
+          }
+        }
+      }
+    }
+  }
+`;
+
+const organismQuery = `
+  query Organism($id: Int!, $simulation: ID) {
+    organism(id: $id, simulation: $simulation) {
+      id
+      action
+      age
+      alive
+      bornAt
+      deathCause
+      diedAt
+      mass
+      mobility
+      position {
+        x
+        y
+      }
+      species {
+        id
+        diet
+        name
+      }
+      cells {
+        alive
+      }
+    }
+  }
+`;
+
+const state = {
+  area: null,
+  environment: null,
+  miniMap: [],
+  organism: null,
+  organismId: null,
+};
+
+const canvas = document.getElementById("map");
+const ctx = canvas.getContext("2d");
+
+async function query(text, variables) {
+  const headers = { "Content-Type": "application/json" };
+  if (apiKey) {
+    headers["X-API-Key"] = apiKey;
+  }
+
+  const response = await fetch("api", {
+    body: JSON.stringify({
+      query: text,
+      variables: Object.assign({ simulation }, variables),
+    }),
+    headers,
+    method: "POST",
+  });
+  if (!response.ok) {
+    throw new Error(`${response.status} ${await response.text()}`);
+  }
+
+  const result = await response.json();
+  if (result.errors && result.errors.length > 0) {
+    throw new Error(result.errors.map((error) => error.message).join(", "));
+  }
+
+  return result.data;
+}
+
+function showError(error) {
+  const element = document.getElementById("error");
+  element.hidden = !error;
+  element.textContent = error ? error.message : "";
+}
+
+function getDietColor(diets) {
+  if (diets.length > 1) {
+    return dietColors.mixed;
+  }
+
+  return dietColors[diets[0]] || dietColors.mixed;
+}
+
+function setField(parent, field, value) {
+  parent.querySelector(`[data-field="${field}"]`).textContent = value;
+}
+
+function drawMap() {
+  ctx.fillStyle = "#080a18";
+  ctx.fillRect(0, 0, canvas.width, canvas.height);
+
+  for (const pixel of state.miniMap) {
+    ctx.fillStyle = getDietColor(pixel.diets);
+    ctx.fillRect(
+      pixel.position.x * pixelSize,
+      pixel.position.y * pixelSize,
+      pixelSize,
+      pixelSize
+    );
+  }
+
+  const scale = pixelSize / miniMapScale;
+  ctx.strokeStyle = "#ffffff";
+  if (state.area) {
+    ctx.strokeRect(
+      state.area.start.x * scale,
+      state.area.start.y * scale,
+      miniMapScale * scale,
+      miniMapScale * scale
+    );
+  }
+  if (state.organism && state.organism.alive) {
+    const { x, y } = state.organism.position;
+    ctx.beginPath();
+    ctx.arc(x * scale, y * scale, pixelSize, 0, 2 * Math.PI);
+    ctx.stroke();
+  }
+}
+
+function drawIteration(iteration) {
+  const element = document.getElementById("iteration");
+  const species = iteration.procreation.species.filter(
+    (species) => species.count > 0
+  );
+
+  setField(element, "number", iteration.number);
+  setField(element, "aliveCellCount", iteration.aliveCellCount);
+  setField(element, "cellCount", iteration.cellCount);
+  setField(element, "toxicity", iteration.waste.toxicity.toFixed(4));
+  setField(element, "species", species.length);
+
+  const tbody = document.querySelector("#species tbody");
+  tbody.textContent = "";
+  species
+    .sort((a, b) => b.count - a.count)
+    .forEach((species) => {
+      const row = tbody.insertRow();
+      row.insertCell().textContent = species.name;
+      row.insertCell().textContent = species.diet.join(", ");
+      row.insertCell().textContent = species.count;
+    });
+}
+
+function drawArea(data) {
+  const section = document.getElementById("area");
+  const list = section.querySelector("ul");
+  section.hidden = false;
+  list.textContent = "";
+
+  const { start } = state.area;
+  setField(
+    section,
+    "bounds",
+    `${start.x}, ${start.y} - ${start.x + miniMapScale}, ${start.y + miniMapScale}`
+  );
+
+  const species = {};
+  for (const element of data.speciesGrid) {
+    for (const s of element.species) {
+      species[s.id] = s;
+    }
+  }
+  for (const s of Object.values(species)) {
+    const item = document.createElement("li");
+    item.textContent = `${s.name} (${s.count} organisms)`;
+    list.appendChild(item);
+  }
+
+  for (const { node } of data.organismList.edges) {
+    const item = document.createElement("li");
+    const link = document.createElement("a");
+    link.textContent = `Organism #${node.id}`;
+    link.addEventListener("click", () => inspect(node.id));
+    item.appendChild(link);
+    list.appendChild(item);
+  }
+}
+
+function drawOrganism() {
+  const section = document.getElementById("organism");
+  const list = section.querySelector("dl");
+  const organism = state.organism;
+  section.hidden = !organism;
+  list.textContent = "";
+  if (!organism) {
+    return;
+  }
+
+  const fields = [
+    ["ID", organism.id],
+    ["Species", organism.species.name],
+    ["Diet", organism.species.diet.join(", ")],
+    ["Age", organism.age],
+    ["Born at", organism.bornAt],
+    ["Mass", organism.mass],
+    ["Mobility", organism.mobility],
+    [
+      "Cells",
+      `${organism.cells.filter((cell) => cell.alive).length} / ${organism.cells.length} alive`,
+    ],
+    ["Action", organism.action],
+    [
+      "Position",
+      `${organism.position.x.toFixed(0)}, ${organism.position.y.toFixed(0)}`,
+    ],
+  ];
+  if (!organism.alive) {
+    fields.push(["Died at", organism.diedAt], ["Cause", organism.deathCause]);
+  }
+
+  for (const [label, value] of fields) {
+    const dt = document.createElement("dt");
+    const dd = document.createElement("dd");
+    dt.textContent = label;
+    dd.textContent = value;
+    list.append(dt, dd);
+  }
+}
+
+async function inspect(id) {
+  state.organismId = id;
+  const data = await query(organismQuery, { id });
+  state.organism = data.organism;
+  drawOrganism();
+  drawMap();
+}
+
+async function selectArea(event) {
+  const rect = canvas.getBoundingClientRect();
+  const x = ((event.clientX - rect.left) / rect.width) * canvas.width;
+  const y = ((event.clientY - rect.top) / rect.height) * canvas.height;
+  const point = {
+    x: (x / pixelSize) * miniMapScale,
+    y: (y / pixelSize) * miniMapScale,
+  };
+  const start = {
+    x: Math.floor(point.x / miniMapScale) * miniMapScale,
+    y: Math.floor(point.y / miniMapScale) * miniMapScale,
+  };
+
+  state.area = {
+    start,
+    end: { x: start.x + miniMapScale, y: start.y + miniMapScale },
+    scale: miniMapScale,
+  };
+
+  try {
+    const data = await query(areaQuery, { area: state.area });
+    drawArea(data);
+
+    // Organism closest to clicked point is inspected right away
+    const nearest = data.organismList.edges
+      .map(({ node }) => node)
+      .sort(
+        (a, b) =>
+          Math.hypot(a.position.x - point.x, a.position.y - point.y) -
+          Math.hypot(b.position.x - point.x, b.position.y - point.y)
+      )[0];
+    if (nearest) {
+      await inspect(nearest.id);
+    }
+    drawMap();
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+}
+
+async function poll() {
+  try {
+    if (!state.environment) {
+      const data = await query(environmentQuery);
+      state.environment = data.environment;
+      canvas.width = Math.ceil(state.environment.width / miniMapScale) * pixelSize;
+      canvas.height = Math.ceil(state.environment.height / miniMapScale) * pixelSize;
+    }
+
+    const data = await query(pollQuery);
+    state.miniMap = data.miniMap;
+    drawIteration(data.iteration);
+
+    if (state.organismId !== null) {
+      await inspect(state.organismId);
+    }
+    drawMap();
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+
+  setTimeout(poll, pollInterval);
+}
+
+canvas.addEventListener("click", selectArea);
+poll();